@@ -34,3 +34,13 @@ func TestAssertDoesNotPanic(t *testing.T) {
 
 	unsafex.Assert(true)
 }
+
+func TestAlignAssertsPowerOfTwo(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected alignment to a non-power of two to panic")
+		}
+	}()
+
+	unsafex.AlignForward(10, 12)
+}
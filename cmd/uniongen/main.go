@@ -0,0 +1,374 @@
+// Command uniongen generates strongly-typed accessors for a union.
+//
+// Given an alias to a union.Of, uniongen emits a named type embedding the union
+// along with methods for each member and an exhaustive visitor interface:
+//
+//	//go:generate go run github.com/judah-caruso/unsafex/cmd/uniongen -type exprOf -name Expr
+//
+//	type exprOf = union.Of[struct {
+//		binaryExpr
+//		intExpr
+//	}]
+//
+// Generates:
+//
+//	type Expr struct{ exprOf }
+//
+//	func (u Expr) IsIntExpr() bool
+//	func (u Expr) AsIntExpr() (intExpr, bool)
+//	func (u *Expr) SetIntExpr(v intExpr)
+//	...
+//
+//	type ExprVisitor interface {
+//		VisitBinaryExpr(v binaryExpr)
+//		VisitIntExpr(v intExpr)
+//	}
+//
+//	func (u Expr) Visit(v ExprVisitor) bool
+//
+// Method names are derived from the name of each member, which can be overridden
+// with a 'union' struct tag. Because the generated type embeds the union, it has the
+// same storage layout and works with every function in the union package.
+//
+// Usage:
+//
+//	uniongen -type alias [-name type] [-output file] [dir]
+//
+// The directory defaults to the current directory. The name defaults to the
+// exported form of the alias, and the output defaults to <name>_union.go.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const unionPath = "github.com/judah-caruso/unsafex/union"
+
+func main() {
+	typeName := flag.String("type", "", "name of the union.Of alias (required)")
+	name := flag.String("name", "", "name of the generated type (default: exported form of -type)")
+	output := flag.String("output", "", "output file name (default: <name>_union.go)")
+	flag.Parse()
+
+	if *typeName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	if *name == "" {
+		*name = export(*typeName)
+	}
+
+	if *output == "" {
+		*output = strings.ToLower(*name) + "_union.go"
+	}
+
+	src, err := generateDir(dir, *typeName, *name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "uniongen:", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, *output), src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "uniongen:", err)
+		os.Exit(1)
+	}
+}
+
+// generateDir finds the union alias in the package within dir and generates its accessors.
+func generateDir(dir, typeName, name string) ([]byte, error) {
+	fset := token.NewFileSet()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		src, err := generate(fset, file, typeName, name)
+		if errors.Is(err, errNotFound) {
+			continue
+		}
+
+		return src, err
+	}
+
+	return nil, fmt.Errorf("%s - %w", typeName, errNotFound)
+}
+
+var errNotFound = errors.New("union.Of alias not found")
+
+// member is a member of the union being generated.
+type member struct {
+	name string // Exported name used for methods.
+	typ  string // Type expression as written in the source.
+}
+
+// generate emits accessors for the union alias typeName declared in file.
+func generate(fset *token.FileSet, file *ast.File, typeName, name string) ([]byte, error) {
+	if typeName == name {
+		return nil, fmt.Errorf("generated type %s must differ from the alias, use -name", name)
+	}
+
+	local := importName(file, unionPath)
+	if local == "" {
+		return nil, errNotFound
+	}
+
+	fields, err := findUnion(file, local, typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		members []member
+		used    = map[string]bool{}
+	)
+	for _, field := range fields.List {
+		if len(field.Names) != 0 {
+			return nil, fmt.Errorf("%s: member %s is not embedded", fset.Position(field.Pos()), field.Names[0])
+		}
+
+		var typ bytes.Buffer
+		if err := format.Node(&typ, fset, field.Type); err != nil {
+			return nil, err
+		}
+
+		memberName := fieldName(field.Type)
+		if field.Tag != nil {
+			tag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			if override := reflect.StructTag(tag).Get("union"); override != "" {
+				memberName = override
+			}
+		}
+
+		m := member{name: export(memberName), typ: typ.String()}
+		if slices.ContainsFunc(members, func(o member) bool { return o.name == m.name }) {
+			return nil, fmt.Errorf("%s: member name %s is used more than once", fset.Position(field.Pos()), m.name)
+		}
+
+		members = append(members, m)
+		ast.Inspect(field.Type, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if ident, ok := sel.X.(*ast.Ident); ok {
+					used[ident.Name] = true
+				}
+			}
+			return true
+		})
+	}
+
+	used[local] = true
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by uniongen -type %s -name %s; DO NOT EDIT.\n\n", typeName, name)
+	fmt.Fprintf(&b, "package %s\n\n", file.Name.Name)
+
+	dir := filepath.Dir(fset.Position(file.Package).Filename)
+	b.WriteString("import (\n")
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil {
+			if used[spec.Name.Name] {
+				fmt.Fprintf(&b, "%s %q\n", spec.Name.Name, path)
+			}
+			continue
+		}
+
+		// The package name is written explicitly when it can't be inferred from the path (e.g. math/rand/v2).
+		if pkg := packageName(path, dir); used[pkg] {
+			if pkg != filepath.Base(path) {
+				fmt.Fprintf(&b, "%s ", pkg)
+			}
+			fmt.Fprintf(&b, "%q\n", path)
+		}
+	}
+	b.WriteString(")\n\n")
+
+	fmt.Fprintf(&b, "// %s is a union of the members of %s with strongly-typed accessors.\n", name, typeName)
+	fmt.Fprintf(&b, "type %s struct {\n\t%s\n}\n\n", name, typeName)
+
+	for _, m := range members {
+		fmt.Fprintf(&b, "// Is%s returns true if %s is the active member of the union.\n", m.name, m.typ)
+		fmt.Fprintf(&b, "func (u %s) Is%s() bool {\n\treturn %s.Is[%s](u)\n}\n\n", name, m.name, local, m.typ)
+
+		fmt.Fprintf(&b, "// As%s returns the value of the active member, or false if %s is not the active member.\n", m.name, m.typ)
+		fmt.Fprintf(&b, "func (u %s) As%s() (%s, bool) {\n", name, m.name, m.typ)
+		fmt.Fprintf(&b, "\tv, err := %s.GetSafe[%s](u)\n\treturn v, err == nil\n}\n\n", local, m.typ)
+
+		fmt.Fprintf(&b, "// Set%s stores v in the union, making %s the active member.\n", m.name, m.typ)
+		fmt.Fprintf(&b, "func (u *%s) Set%s(v %s) {\n\t%s.Set(u, v)\n}\n\n", name, m.name, m.typ, local)
+	}
+
+	fmt.Fprintf(&b, "// %sVisitor handles every member of %s.\n", name, name)
+	fmt.Fprintf(&b, "type %sVisitor interface {\n", name)
+	for _, m := range members {
+		fmt.Fprintf(&b, "\tVisit%s(v %s)\n", m.name, m.typ)
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(&b, "// Visit calls the method of v for the active member, returning false if the union is uninitialized.\n")
+	fmt.Fprintf(&b, "func (u %s) Visit(v %sVisitor) bool {\n", name, name)
+	b.WriteString("\tm, ok := u.Which()\n\tif !ok {\n\t\treturn false\n\t}\n\n\tswitch m.Index {\n")
+	for i, m := range members {
+		fmt.Fprintf(&b, "\tcase %d:\n\t\tv.Visit%s(%s.Get[%s](u))\n", i, m.name, local, m.typ)
+	}
+	b.WriteString("\t}\n\n\treturn true\n}\n")
+
+	return format.Source(b.Bytes())
+}
+
+// importName returns the name a file uses to refer to the package at path, or an empty string if it isn't imported.
+func importName(file *ast.File, path string) string {
+	for _, spec := range file.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); p != path {
+			continue
+		}
+
+		if spec.Name != nil {
+			return spec.Name.Name
+		}
+
+		return filepath.Base(path)
+	}
+
+	return ""
+}
+
+// packageName returns the name of the package at path imported from dir.
+//
+// If the package can't be found, the name is guessed from the path following
+// the usual conventions for major versions (e.g. gopkg.in/yaml.v3 is yaml).
+func packageName(path, dir string) string {
+	// Resolved with -mod=readonly so looking up an import never edits the caller's go.mod.
+	cmd := exec.Command("go", "list", "-mod=readonly", "-e", "-f", "{{.Name}}", "--", path)
+	cmd.Dir = dir
+	if out, err := cmd.Output(); err == nil {
+		if name := strings.TrimSpace(string(out)); name != "" {
+			return name
+		}
+	}
+
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && isMajorVersion(name) {
+		name = elems[len(elems)-2]
+	}
+
+	if i := strings.LastIndex(name, ".v"); i > 0 && isMajorVersion(name[i+1:]) {
+		name = name[:i]
+	}
+
+	return name
+}
+
+// isMajorVersion returns true if s is a major version suffix such as v2.
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+
+	_, err := strconv.Atoi(s[1:])
+	return err == nil
+}
+
+// findUnion returns the members of the union.Of alias with the given name.
+func findUnion(file *ast.File, local, typeName string) (*ast.FieldList, error) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if ts.Name.Name != typeName {
+				continue
+			}
+
+			if !ts.Assign.IsValid() {
+				return nil, fmt.Errorf("%s must be an alias (type %s = %s.Of[...])", typeName, typeName, local)
+			}
+
+			index, ok := ts.Type.(*ast.IndexExpr)
+			if !ok {
+				break
+			}
+
+			sel, ok := index.X.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Of" {
+				break
+			}
+
+			if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != local {
+				break
+			}
+
+			members, ok := index.Index.(*ast.StructType)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a union of a struct type", typeName)
+			}
+
+			return members.Fields, nil
+		}
+	}
+
+	return nil, errNotFound
+}
+
+// fieldName returns the name of an embedded field with the given type.
+func fieldName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.StarExpr:
+		return fieldName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.IndexExpr:
+		return fieldName(e.X)
+	case *ast.IndexListExpr:
+		return fieldName(e.X)
+	}
+
+	return ""
+}
+
+// export returns name with its first letter in upper case.
+func export(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := generateDir("testdata/expr", "exprOf", "Expr")
+	if err != nil {
+		t.Fatalf("failed to generate union: %s", err)
+	}
+
+	for _, expected := range []string{
+		"type Expr struct {\n\texprOf\n}",
+		"func (u Expr) IsBinaryExpr() bool {\n\treturn union.Is[binaryExpr](u)\n}",
+		"func (u Expr) AsIntExpr() (intExpr, bool) {",
+		"func (u *Expr) SetDuration(v *time.Duration) {",
+		"type ExprVisitor interface {\n\tVisitBinaryExpr(v binaryExpr)\n\tVisitIntExpr(v intExpr)\n\tVisitDuration(v *time.Duration)\n\tVisitRand(v *rand.Rand)\n}",
+		"\"time\"",
+		"rand \"math/rand/v2\"",
+	} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("expected generated code to contain:\n%s\n\ngenerated:\n%s", expected, src)
+		}
+	}
+
+	// The generated code must type-check alongside the package it was generated for.
+	fset := token.NewFileSet()
+	original, err := parser.ParseFile(fset, "testdata/expr/expr.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generated, err := parser.ParseFile(fset, "expr_union.go", src, 0)
+	if err != nil {
+		t.Fatalf("failed to parse generated code: %s", err)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("expr", fset, []*ast.File{original, generated}, nil)
+	if err != nil {
+		t.Fatalf("generated code does not type-check: %s\n%s", err, src)
+	}
+
+	visitor := pkg.Scope().Lookup("ExprVisitor").Type().Underlying().(*types.Interface)
+	eval := types.NewPointer(pkg.Scope().Lookup("eval").Type())
+	if !types.Implements(eval, visitor) {
+		t.Error("expected eval to implement the generated visitor")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	cases := []struct{ src, typ, expected string }{
+		{"package p\nimport \"github.com/judah-caruso/unsafex/union\"\ntype v union.Of[struct{ int }]", "v", "must be an alias"},
+		{"package p\nimport \"github.com/judah-caruso/unsafex/union\"\ntype v = union.Of[struct{ A int }]", "v", "is not embedded"},
+		{"package p\nimport \"github.com/judah-caruso/unsafex/union\"\ntype v = union.Of[int]", "v", "must be a union of a struct type"},
+		{"package p\nimport \"github.com/judah-caruso/unsafex/union\"\ntype v = union.Of[struct{ int `union:\"x\"`; bool `union:\"x\"` }]", "v", "used more than once"},
+		{"package p\ntype v = int", "v", "not found"},
+	}
+
+	for _, c := range cases {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, "p.go", c.src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = generate(fset, file, c.typ, "V")
+		if err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("expected error containing %q, got %v", c.expected, err)
+		}
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	cases := []struct{ path, expected string }{
+		{"time", "time"},
+		{"math/rand/v2", "rand"},
+		{"example.com/missing/yaml/v3", "yaml"},
+		{"gopkg.in/yaml.v3", "yaml"},
+		{"example.com/go.value", "go.value"},
+	}
+
+	for _, c := range cases {
+		if name := packageName(c.path, "."); name != c.expected {
+			t.Errorf("expected the package name of %s to be %s, was %s", c.path, c.expected, name)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package expr
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/judah-caruso/unsafex/union"
+)
+
+type (
+	exprOf = union.Of[struct {
+		binaryExpr
+		intExpr
+		*time.Duration `union:"duration"`
+		*rand.Rand
+	}]
+	binaryExpr struct {
+		Op       string
+		Lhs, Rhs *Expr
+	}
+	intExpr int64
+)
+
+// eval is implemented in terms of the generated visitor to ensure it's usable.
+type eval struct{ result float64 }
+
+func (e *eval) VisitBinaryExpr(v binaryExpr) {
+	lhs, rhs := &eval{}, &eval{}
+	v.Lhs.Visit(lhs)
+	v.Rhs.Visit(rhs)
+	e.result = lhs.result + rhs.result
+}
+
+func (e *eval) VisitIntExpr(v intExpr) {
+	e.result = float64(v)
+}
+
+func (e *eval) VisitDuration(v *time.Duration) {
+	e.result = v.Seconds()
+}
+
+func (e *eval) VisitRand(v *rand.Rand) {
+	e.result = v.Float64()
+}
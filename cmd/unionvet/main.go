@@ -0,0 +1,265 @@
+// Command unionvet reports invalid instantiations of union.Of.
+//
+// It applies the same rules as union.Validate, but at build time:
+// the type given to union.Of must be a struct of at most 255 embedded
+// members with distinct types and distinct names (including names given
+// by a 'union' struct tag).
+//
+// Usage:
+//
+//	go run github.com/judah-caruso/unsafex/cmd/unionvet [packages]
+//
+// Packages are given as directories, where a trailing '/...' also checks
+// every package below the directory. If no packages are given, the current
+// directory is checked. Diagnostics are printed in the same format as go vet,
+// and the command exits with a non-zero status if any are found.
+//
+// Note: unionvet is a standalone command that only mimics the output of go vet;
+// it is not a go/analysis analyzer and can't be run with go vet -vettool. Packages
+// are loaded with go/build's default context and type-checked from source, so files
+// are selected for the host GOOS and GOARCH and custom build tags are ignored.
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const (
+	unionPath  = "github.com/judah-caruso/unsafex/union"
+	maxMembers = 255
+)
+
+func main() {
+	patterns := os.Args[1:]
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	dirs, err := expand(patterns)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unionvet:", err)
+		os.Exit(2)
+	}
+
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+
+	found := false
+	for _, dir := range dirs {
+		diags, err := checkDir(fset, imp, dir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "unionvet:", err)
+			os.Exit(2)
+		}
+
+		for _, d := range diags {
+			fmt.Fprintln(os.Stderr, d)
+			found = true
+		}
+	}
+
+	if found {
+		os.Exit(1)
+	}
+}
+
+// diagnostic is a problem found at a position in the source.
+type diagnostic struct {
+	pos token.Position
+	msg string
+}
+
+func (d diagnostic) String() string {
+	return fmt.Sprintf("%s: %s", d.pos, d.msg)
+}
+
+// expand converts package patterns into a list of directories.
+func expand(patterns []string) ([]string, error) {
+	var dirs []string
+	for _, pattern := range patterns {
+		root, recursive := strings.CutSuffix(pattern, "/...")
+		if !recursive {
+			dirs = append(dirs, pattern)
+			continue
+		}
+
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !d.IsDir() {
+				return nil
+			}
+
+			name := d.Name()
+			if path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+
+			dirs = append(dirs, path)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dirs, nil
+}
+
+// checkDir type-checks the package in dir (along with its tests) and reports invalid unions.
+//
+// Directories without Go files are ignored.
+func checkDir(fset *token.FileSet, imp types.Importer, dir string) ([]diagnostic, error) {
+	pkg, err := build.ImportDir(dir, build.ImportComment)
+	if err != nil {
+		var noGo *build.NoGoError
+		if errors.As(err, &noGo) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var diags []diagnostic
+	for _, files := range [][]string{
+		append(pkg.GoFiles, pkg.TestGoFiles...),
+		pkg.XTestGoFiles,
+	} {
+		if len(files) == 0 {
+			continue
+		}
+
+		found, err := checkFiles(fset, imp, dir, files)
+		if err != nil {
+			return nil, err
+		}
+
+		diags = append(diags, found...)
+	}
+
+	slices.SortFunc(diags, func(a, b diagnostic) int {
+		return cmp.Or(
+			strings.Compare(a.pos.Filename, b.pos.Filename),
+			cmp.Compare(a.pos.Offset, b.pos.Offset),
+			strings.Compare(a.msg, b.msg),
+		)
+	})
+
+	return diags, nil
+}
+
+// checkFiles type-checks a single package made of the given files and reports invalid unions.
+func checkFiles(fset *token.FileSet, imp types.Importer, dir string, names []string) ([]diagnostic, error) {
+	var files []*ast.File
+	for _, name := range names {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	info := &types.Info{Instances: make(map[*ast.Ident]types.Instance)}
+	conf := types.Config{Importer: imp}
+	if _, err := conf.Check(dir, fset, files, info); err != nil {
+		return nil, err
+	}
+
+	var diags []diagnostic
+	for ident, inst := range info.Instances {
+		named, ok := inst.Type.(*types.Named)
+		if !ok || !isUnion(named) {
+			continue
+		}
+
+		for _, problem := range validate(named.TypeArgs().At(0)) {
+			diags = append(diags, diagnostic{
+				pos: fset.Position(ident.Pos()),
+				msg: "invalid union.Of: " + problem,
+			})
+		}
+	}
+
+	return diags, nil
+}
+
+// isUnion returns true if the named type is an instantiation of union.Of.
+func isUnion(named *types.Named) bool {
+	obj := named.Origin().Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == unionPath && obj.Name() == "Of"
+}
+
+// validate mirrors union.Validate, returning a description of every problem with a union's internal type.
+//
+// Type parameters are not validated as they're checked once instantiated.
+func validate(t types.Type) []string {
+	if _, ok := t.(*types.TypeParam); ok {
+		return nil
+	}
+
+	s, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return []string{fmt.Sprintf("%s is not a struct", t)}
+	}
+
+	if s.NumFields() == 0 {
+		return []string{fmt.Sprintf("%s has no members", t)}
+	}
+
+	var problems []string
+	names := make(map[string]int)
+	if s.NumFields() > maxMembers {
+		problems = append(problems, fmt.Sprintf("%d members exceeds the limit of %d", s.NumFields(), maxMembers))
+	}
+
+	for i := range s.NumFields() {
+		field := s.Field(i)
+		switch {
+		case field.Name() == "_":
+			problems = append(problems, fmt.Sprintf("member %d (%s) is a blank field", i, field.Type()))
+		case !field.Embedded():
+			problems = append(problems, fmt.Sprintf("member %d (%s %s) is not embedded", i, field.Name(), field.Type()))
+		}
+
+		for j := range i {
+			if types.Identical(field.Type(), s.Field(j).Type()) {
+				problems = append(problems, fmt.Sprintf("member %d (%s) has the same type as member %d", i, field.Type(), j))
+				break
+			}
+		}
+
+		if field.Name() == "_" {
+			continue
+		}
+
+		name := field.Name()
+		if override := reflect.StructTag(s.Tag(i)).Get("union"); override != "" {
+			name = override
+		}
+
+		if first, ok := names[name]; ok {
+			problems = append(problems, fmt.Sprintf("member %d (%s) has the same name %q as member %d", i, field.Type(), name, first))
+		} else {
+			names[name] = i
+		}
+	}
+
+	return problems
+}
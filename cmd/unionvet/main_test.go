@@ -0,0 +1,33 @@
+package main
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+)
+
+func TestCheckDir(t *testing.T) {
+	fset := token.NewFileSet()
+	diags, err := checkDir(fset, importer.ForCompiler(fset, "source", nil), "testdata/invalid")
+	if err != nil {
+		t.Fatalf("failed to check package: %s", err)
+	}
+
+	expected := []string{
+		"testdata/invalid/invalid.go:11:22: invalid union.Of: member 1 (A int32) is not embedded",
+		"testdata/invalid/invalid.go:11:22: invalid union.Of: member 1 (int32) has the same type as member 0",
+		"testdata/invalid/invalid.go:16:16: invalid union.Of: member 0 (float32) is a blank field",
+		"testdata/invalid/invalid.go:20:20: invalid union.Of: int is not a struct",
+		"testdata/invalid/invalid.go:22:19: invalid union.Of: member 1 (int64) has the same name \"n\" as member 0",
+	}
+
+	if len(diags) != len(expected) {
+		t.Fatalf("expected %d diagnostics, got %d: %v", len(expected), len(diags), diags)
+	}
+
+	for i, d := range diags {
+		if d.String() != expected[i] {
+			t.Errorf("unexpected diagnostic:\n%s\n%s", d, expected[i])
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package invalid
+
+import "github.com/judah-caruso/unsafex/union"
+
+type (
+	Valid = union.Of[struct {
+		int32
+		float32
+	}]
+
+	NotEmbedded = union.Of[struct {
+		int32
+		A int32
+	}]
+
+	Blank = union.Of[struct {
+		_ float32
+	}]
+
+	NotStruct = union.Of[int]
+
+	SameName = union.Of[struct {
+		int32 `union:"n"`
+		int64 `union:"n"`
+	}]
+)
+
+func set[T any](u *union.Of[T]) {}
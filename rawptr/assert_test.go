@@ -0,0 +1,67 @@
+//go:build !UNSAFEX_DISABLE_ASSERT
+
+package rawptr_test
+
+import (
+	"testing"
+
+	"github.com/judah-caruso/unsafex/rawptr"
+)
+
+func TestSpanBounds(t *testing.T) {
+	values := []uint8{1, 2, 3}
+	span := rawptr.SpanFrom(values)
+
+	cases := []struct {
+		name string
+		fn   func()
+	}{
+		{"At past end", func() { span.At(3) }},
+		{"At negative", func() { span.At(-1) }},
+		{"Set past end", func() { span.Set(3, 0) }},
+		{"Sub past end", func() { span.Sub(1, 4) }},
+		{"Sub inverted", func() { span.Sub(2, 1) }},
+		{"Sub of sub", func() { span.Sub(1, 2).At(1) }},
+		{"negative length", func() { rawptr.SpanOf(span.Ptr(), -1) }},
+	}
+
+	for _, c := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected out of bounds access to panic", c.name)
+				}
+			}()
+
+			c.fn()
+		}()
+	}
+}
+
+func TestAlignToAssertsPowerOfTwo(t *testing.T) {
+	val := uint64(0)
+	ptr := rawptr.From(&val)
+
+	cases := []struct {
+		name string
+		fn   func()
+	}{
+		{"AlignForwardTo", func() { ptr.AlignForwardTo(24) }},
+		{"AlignBackwardTo", func() { ptr.AlignBackwardTo(0) }},
+		{"IsAlignedTo", func() { ptr.IsAlignedTo(3) }},
+		{"AlignTo", func() { ptr.AlignTo(48) }},
+		{"AlignedDownTo", func() { ptr.AlignedDownTo(12) }},
+	}
+
+	for _, c := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected a non-power of two alignment to panic", c.name)
+				}
+			}()
+
+			c.fn()
+		}()
+	}
+}
@@ -4,4 +4,9 @@
 // require fewer intermediate variables or casts. It does not change the
 // rules or semantics around a regular unsafe.Pointer, so one should still
 // be cautious when using this package.
+//
+// Raw pointers come in two forms: P holds an unsafe.Pointer and is tracked by
+// the garbage collector, while T holds a uintptr and is not. Use P for memory
+// managed by Go and T for memory that isn't (e.g. from mmap or C), converting
+// between them with T.Pointer and P.Addr.
 package rawptr
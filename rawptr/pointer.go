@@ -0,0 +1,190 @@
+package rawptr
+
+import (
+	"unsafe"
+
+	"github.com/judah-caruso/unsafex"
+)
+
+// P represents a pointer into memory with an associated type.
+//
+// Unlike T, P is backed by an unsafe.Pointer so the garbage collector can see it. This keeps
+// the memory it points to alive and valid if the object is moved, making P the right choice
+// for pointers into memory managed by Go. T should only be used for memory Go doesn't manage
+// (e.g. memory returned by mmap or C) or addresses that are never dereferenced.
+//
+// Note: P follows the same rules and patterns as unsafe.Pointer, including that
+// arithmetic must not move a pointer outside of the object it points into.
+type P[Underlying any] struct {
+	ptr unsafe.Pointer
+}
+
+// FromP converts a pointer to a garbage collected raw pointer.
+func FromP[Underlying any](value *Underlying) P[Underlying] {
+	return P[Underlying]{ptr: unsafe.Pointer(value)}
+}
+
+// ToP converts a garbage collected raw pointer into a pointer of the given type.
+func ToP[To, From any](p P[From]) *To {
+	return (*To)(p.ptr)
+}
+
+// CastP converts a garbage collected raw pointer of one type to another.
+func CastP[To, From any](p P[From]) P[To] {
+	return P[To](p)
+}
+
+// Pointer converts a raw pointer to a garbage collected raw pointer.
+//
+// Note: the address must point to valid memory, as the garbage collector may inspect it once converted.
+// Pointer is meant for memory Go doesn't manage; converting the address of Go memory is rejected by
+// checkptr (enabled by -race) and is only safe while something else keeps that memory alive.
+func (p T[Underlying]) Pointer() P[Underlying] {
+	return P[Underlying]{ptr: unsafe.Pointer(To[Underlying](p))}
+}
+
+// Addr returns the address of a garbage collected raw pointer.
+//
+// Note: the returned raw pointer does not keep the memory it points to alive.
+func (p P[Underlying]) Addr() T[Underlying] {
+	return T[Underlying](uintptr(p.ptr))
+}
+
+// IsNil returns if a garbage collected raw pointer is nil.
+func (p P[Underlying]) IsNil() bool {
+	return p.ptr == nil
+}
+
+// Size returns the size in bytes of the type associated with this raw pointer.
+func (p P[Underlying]) Size() uintptr {
+	return unsafex.SizeOf[Underlying]()
+}
+
+// Alignment returns the required alignment in bytes of the type associated with this raw pointer.
+func (p P[Underlying]) Alignment() uintptr {
+	return unsafex.AlignOf[Underlying]()
+}
+
+// IsAligned returns if a raw pointer is aligned to a power of two address.
+func (p P[Underlying]) IsAligned() bool {
+	return unsafex.IsAligned(uintptr(p.ptr), p.Alignment())
+}
+
+// IsAlignedTo returns if a raw pointer is aligned to a multiple of n, which must be a power of two.
+func (p P[Underlying]) IsAlignedTo(n uintptr) bool {
+	return unsafex.IsAligned(uintptr(p.ptr), n)
+}
+
+// AlignForward aligns a raw pointer to the next aligned address following the alignment rules of its associated type.
+//
+// Note: AlignForward does nothing if the address is already aligned.
+func (p *P[Underlying]) AlignForward() {
+	*p = p.AlignedUp()
+}
+
+// AlignBackward aligns a raw pointer to the previous aligned address following the alignment rules of its associated type.
+//
+// Note: AlignBackward does nothing if the address is already aligned.
+func (p *P[Underlying]) AlignBackward() {
+	*p = p.AlignedDown()
+}
+
+// AlignForwardTo aligns a raw pointer to the next multiple of n, which must be a power of two.
+// This allows alignments stricter than its associated type, such as cache lines or pages.
+//
+// Note: AlignForwardTo does nothing if the address is already aligned.
+func (p *P[Underlying]) AlignForwardTo(n uintptr) {
+	*p = p.AlignTo(n)
+}
+
+// AlignBackwardTo aligns a raw pointer to the previous multiple of n, which must be a power of two.
+//
+// Note: AlignBackwardTo does nothing if the address is already aligned.
+func (p *P[Underlying]) AlignBackwardTo(n uintptr) {
+	*p = p.AlignedDownTo(n)
+}
+
+// Deref safely dereferences a raw pointer and returns its value or its zero value if the pointer was nil.
+func (p P[Underlying]) Deref() Underlying {
+	if p.ptr == nil {
+		var zero Underlying
+		return zero
+	}
+
+	return *(*Underlying)(p.ptr)
+}
+
+// Add modifies a raw pointer by incrementing its address by the given amount.
+//
+// Note: Add does not align the new address. Use AlignForward or AlignBackward.
+func (p *P[Underlying]) Add(amt uintptr) {
+	*p = p.Plus(amt)
+}
+
+// Sub modifies a raw pointer by decrementing its address by the given amount.
+//
+// Note: Sub does not align the new address. Use AlignForward or AlignBackward.
+func (p *P[Underlying]) Sub(amt uintptr) {
+	*p = p.Minus(amt)
+}
+
+// Nth indexes a raw pointer by its associated type and returns a new raw pointer of the same type.
+func (p P[Underlying]) Nth(index int) P[Underlying] {
+	return P[Underlying]{ptr: unsafe.Add(p.ptr, index*int(p.Size()))}
+}
+
+// Offset returns a raw pointer offset by the given number of bytes, which may be negative.
+func (p P[Underlying]) Offset(amt int) P[Underlying] {
+	return P[Underlying]{ptr: unsafe.Add(p.ptr, amt)}
+}
+
+// Plus returns a raw pointer incremented by the given amount.
+//
+// Note: Plus does not align the new address. Use AlignedUp or AlignedDown.
+func (p P[Underlying]) Plus(amt uintptr) P[Underlying] {
+	return P[Underlying]{ptr: unsafe.Add(p.ptr, amt)}
+}
+
+// Minus returns a raw pointer decremented by the given amount.
+//
+// Note: Minus does not align the new address. Use AlignedUp or AlignedDown.
+func (p P[Underlying]) Minus(amt uintptr) P[Underlying] {
+	return P[Underlying]{ptr: unsafe.Add(p.ptr, -int(amt))}
+}
+
+// AlignedUp returns a raw pointer aligned to the next aligned address following the alignment rules of its associated type.
+//
+// Note: AlignedUp returns the same pointer if it's already aligned.
+func (p P[Underlying]) AlignedUp() P[Underlying] {
+	return p.AlignTo(p.Alignment())
+}
+
+// AlignedDown returns a raw pointer aligned to the previous aligned address following the alignment rules of its associated type.
+//
+// Note: AlignedDown returns the same pointer if it's already aligned.
+func (p P[Underlying]) AlignedDown() P[Underlying] {
+	return p.AlignedDownTo(p.Alignment())
+}
+
+// AlignTo returns a raw pointer aligned to the next multiple of n, which must be a power of two.
+//
+// Note: AlignTo returns the same pointer if it's already aligned.
+func (p P[Underlying]) AlignTo(n uintptr) P[Underlying] {
+	addr := uintptr(p.ptr)
+	return p.Plus(unsafex.AlignForward(addr, n) - addr)
+}
+
+// AlignedDownTo returns a raw pointer aligned to the previous multiple of n, which must be a power of two.
+//
+// Note: AlignedDownTo returns the same pointer if it's already aligned.
+func (p P[Underlying]) AlignedDownTo(n uintptr) P[Underlying] {
+	addr := uintptr(p.ptr)
+	return p.Minus(addr - unsafex.AlignBackward(addr, n))
+}
+
+// DiffP returns the distance from b to a in elements of the associated type.
+//
+// Note: the distance is truncated if the pointers are not a multiple of the size apart.
+func DiffP[Underlying any](a, b P[Underlying]) int {
+	return Diff(a.Addr(), b.Addr())
+}
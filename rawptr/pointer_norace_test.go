@@ -0,0 +1,19 @@
+//go:build !race
+
+package rawptr_test
+
+import (
+	"testing"
+
+	"github.com/judah-caruso/unsafex/rawptr"
+)
+
+// Converting the address of Go memory back to a pointer is rejected by checkptr, which the race detector enables.
+func TestPRoundTrip(t *testing.T) {
+	val := uint64(10)
+
+	ptr := rawptr.FromP(&val)
+	if ptr.Addr().Pointer() != ptr {
+		t.Errorf("expected a pointer to round trip through its address")
+	}
+}
@@ -0,0 +1,128 @@
+package rawptr_test
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/judah-caruso/unsafex"
+	"github.com/judah-caruso/unsafex/rawptr"
+)
+
+func TestP(t *testing.T) {
+	var value uint32 = 0xAAAA_FFFF
+
+	ptr := rawptr.FromP(&value)
+	base := ptr
+
+	ptr.Add(unsafex.SizeOf[uint16]())
+	if ptr.Addr() != base.Addr()+2 {
+		t.Errorf("expected ptr to be %X, was %X", base.Addr()+2, ptr.Addr())
+	}
+
+	half := rawptr.ToP[uint16](ptr)
+	if *half != 0xAAAA {
+		t.Errorf("expected half to be 0xAAAA, was %X", *half)
+	}
+
+	*half = 0xBBBB
+	if value != 0xBBBB_FFFF {
+		t.Errorf("expected value to be 0xBBBBFFFF, was %X", value)
+	}
+
+	ptr.Sub(unsafex.SizeOf[uint16]())
+	if ptr != base {
+		t.Errorf("expected Sub to return to the base pointer, was %X", ptr.Addr())
+	}
+}
+
+func TestPCastAndNth(t *testing.T) {
+	values := [2]uint32{0xAAAA_AAAA, 0xBBBB_BBBB}
+
+	bptr := rawptr.CastP[uint16](rawptr.FromP(&values))
+	for i, half := range []uint16{0xFAFA, 0xAFAF, 0xBEEF, 0xDEAD} {
+		*rawptr.ToP[uint16](bptr.Nth(i)) = half
+	}
+
+	if values[0] != 0xAFAF_FAFA || values[1] != 0xDEAD_BEEF {
+		t.Errorf("expected values to be modified through Nth, were %X", values)
+	}
+
+	if v := bptr.Nth(3).Deref(); v != 0xDEAD {
+		t.Errorf("expected Deref to be 0xDEAD, was %X", v)
+	}
+}
+
+func TestPConversion(t *testing.T) {
+	val := uint64(10)
+
+	ptr := rawptr.FromP(&val)
+	if ptr.Addr() != rawptr.From(&val) {
+		t.Errorf("expected Addr to match From, %X vs. %X", ptr.Addr(), rawptr.From(&val))
+	}
+
+	var nilptr rawptr.P[uint64]
+	if !nilptr.IsNil() || nilptr.Addr() != 0 || nilptr.Deref() != 0 {
+		t.Errorf("expected the zero value to be a nil pointer")
+	}
+}
+
+func TestPAlign(t *testing.T) {
+	vals := [2]uint32{}
+	ptr := rawptr.FromP(&vals[1])
+	old := ptr
+
+	ptr.Sub(1)
+	if ptr.IsAligned() {
+		t.Errorf("IsAligned returned true for an unaligned address")
+	}
+
+	ptr.AlignForward()
+	if !ptr.IsAligned() || ptr != old {
+		t.Errorf("AlignForward did not align back to the previous address, new %X, old %X", ptr.Addr(), old.Addr())
+	}
+
+	ptr.Add(1)
+	ptr.AlignBackward()
+	if !ptr.IsAligned() || ptr != old {
+		t.Errorf("AlignBackward did not align back to the previous address, new %X, old %X", ptr.Addr(), old.Addr())
+	}
+}
+
+func TestPKeepsMemoryAlive(t *testing.T) {
+	type object struct {
+		values [64]uint64
+	}
+
+	var freed atomic.Bool
+
+	collect := func() {
+		for range 4 {
+			runtime.GC()
+			time.Sleep(time.Millisecond) // Give finalizers a chance to run.
+		}
+	}
+
+	ptr := func() rawptr.P[uint64] {
+		obj := &object{}
+		obj.values[10] = 0xDEAD_BEEF
+		runtime.SetFinalizer(obj, func(*object) { freed.Store(true) })
+		return rawptr.CastP[uint64](rawptr.FromP(obj)).Nth(10)
+	}()
+
+	collect()
+	if freed.Load() {
+		t.Fatalf("memory referenced by P was freed")
+	}
+
+	if v := ptr.Deref(); v != 0xDEAD_BEEF {
+		t.Errorf("expected value to be 0xDEADBEEF, was %X", v)
+	}
+
+	// Once P is no longer used the memory must be collected, otherwise the check above proves nothing.
+	collect()
+	if !freed.Load() {
+		t.Errorf("memory was not freed after P was dropped")
+	}
+}
@@ -39,9 +39,35 @@ func ToSafe[To, From any](p T[From]) (*To, bool) {
 	return ptr, true
 }
 
+// FromSlice converts the pointer to the first element of a slice to a raw pointer.
+//
+// Returns 0 if the slice has a nil pointer.
+func FromSlice[Underlying any](s []Underlying) T[Underlying] {
+	return From(unsafe.SliceData(s))
+}
+
+// ToSlice returns a slice of n values of the given type starting at a raw pointer.
+func ToSlice[Element, From any](p T[From], n int) []Element {
+	return unsafe.Slice(To[Element](p), n)
+}
+
+// FromString converts the pointer to the bytes of a string to a raw pointer.
+func FromString(s string) T[byte] {
+	return From(unsafe.StringData(s))
+}
+
+// ToString returns a string of n bytes starting at a raw pointer.
+//
+// Because strings in Go are immutable, the memory must not be modified during the lifetime of the returned string.
+func ToString[From any](p T[From], n int) string {
+	return unsafe.String(To[byte](p), n)
+}
+
 // T represents an arbitrary address in memory with an associated type.
 //
 // Note: T follows the same rules and patterns unsafe.Pointer.
+// Because T is a uintptr, the garbage collector does not see it, so memory
+// managed by Go may be freed or moved while a T refers to it. Use P instead.
 type T[Underlying any] uintptr
 
 // Cast converts a raw pointer of one type to another.
@@ -61,22 +87,41 @@ func (p T[Underlying]) Alignment() uintptr {
 
 // IsAligned returns if a raw pointer is aligned to a power of two address.
 func (p T[Underlying]) IsAligned() bool {
-	return uintptr(p)&(p.Alignment()-1) == 0
+	return unsafex.IsAligned(uintptr(p), p.Alignment())
+}
+
+// IsAlignedTo returns if a raw pointer is aligned to a multiple of n, which must be a power of two.
+func (p T[Underlying]) IsAlignedTo(n uintptr) bool {
+	return unsafex.IsAligned(uintptr(p), n)
 }
 
 // AlignForward aligns a raw pointer to the next aligned address following the alignment rules of its associated type.
 //
 // Note: AlignForward does nothing if the address is already aligned.
 func (p *T[Underlying]) AlignForward() {
-	align := p.Alignment()
-	*(*uintptr)(p) = (*(*uintptr)(p) + align - 1) & ^(align - 1)
+	*p = p.AlignedUp()
 }
 
 // AlignBackward aligns a raw pointer to the previous aligned address following the alignment rules of its associated type.
 //
 // Note: AlignBackward does nothing if the address is already aligned.
 func (p *T[Underlying]) AlignBackward() {
-	*(*uintptr)(p) = *(*uintptr)(p) & ^(p.Alignment() - 1)
+	*p = p.AlignedDown()
+}
+
+// AlignForwardTo aligns a raw pointer to the next multiple of n, which must be a power of two.
+// This allows alignments stricter than its associated type, such as cache lines or pages.
+//
+// Note: AlignForwardTo does nothing if the address is already aligned.
+func (p *T[Underlying]) AlignForwardTo(n uintptr) {
+	*p = p.AlignTo(n)
+}
+
+// AlignBackwardTo aligns a raw pointer to the previous multiple of n, which must be a power of two.
+//
+// Note: AlignBackwardTo does nothing if the address is already aligned.
+func (p *T[Underlying]) AlignBackwardTo(n uintptr) {
+	*p = p.AlignedDownTo(n)
 }
 
 // Deref safely dereferences a raw pointer and returns its value or its zero value if the pointer was invalid.
@@ -94,14 +139,14 @@ func (p T[Underlying]) Deref() Underlying {
 //
 // Note: Add does not align the new address. Use AlignForward or AlignBackward.
 func (p *T[Underlying]) Add(amt uintptr) {
-	*(*uintptr)(p) += amt
+	*p = p.Plus(amt)
 }
 
 // Sub modifies a raw pointer by decrementing its address by the given amount.
 //
 // Note: Sub does not align the new address. Use AlignForward or AlignBackward.
 func (p *T[Underlying]) Sub(amt uintptr) {
-	*(*uintptr)(p) -= amt
+	*p = p.Minus(amt)
 }
 
 // Nth indexes a raw pointer by its associated type and returns a new raw pointer of the same type.
@@ -109,3 +154,59 @@ func (p T[Underlying]) Nth(index int) T[Underlying] {
 	nptr := uintptr(p) + uintptr(index)*p.Size()
 	return T[Underlying](nptr)
 }
+
+// Offset returns a raw pointer whose address is offset by the given number of bytes, which may be negative.
+func (p T[Underlying]) Offset(amt int) T[Underlying] {
+	return T[Underlying](uintptr(p) + uintptr(amt))
+}
+
+// Plus returns a raw pointer whose address is incremented by the given amount.
+//
+// Note: Plus does not align the new address. Use AlignedUp or AlignedDown.
+func (p T[Underlying]) Plus(amt uintptr) T[Underlying] {
+	return p + T[Underlying](amt)
+}
+
+// Minus returns a raw pointer whose address is decremented by the given amount.
+//
+// Note: Minus does not align the new address. Use AlignedUp or AlignedDown.
+func (p T[Underlying]) Minus(amt uintptr) T[Underlying] {
+	return p - T[Underlying](amt)
+}
+
+// AlignedUp returns a raw pointer aligned to the next aligned address following the alignment rules of its associated type.
+//
+// Note: AlignedUp returns the same address if it's already aligned.
+func (p T[Underlying]) AlignedUp() T[Underlying] {
+	return p.AlignTo(p.Alignment())
+}
+
+// AlignedDown returns a raw pointer aligned to the previous aligned address following the alignment rules of its associated type.
+//
+// Note: AlignedDown returns the same address if it's already aligned.
+func (p T[Underlying]) AlignedDown() T[Underlying] {
+	return p.AlignedDownTo(p.Alignment())
+}
+
+// AlignTo returns a raw pointer aligned to the next multiple of n, which must be a power of two.
+//
+// Note: AlignTo returns the same address if it's already aligned.
+func (p T[Underlying]) AlignTo(n uintptr) T[Underlying] {
+	return T[Underlying](unsafex.AlignForward(uintptr(p), n))
+}
+
+// AlignedDownTo returns a raw pointer aligned to the previous multiple of n, which must be a power of two.
+//
+// Note: AlignedDownTo returns the same address if it's already aligned.
+func (p T[Underlying]) AlignedDownTo(n uintptr) T[Underlying] {
+	return T[Underlying](unsafex.AlignBackward(uintptr(p), n))
+}
+
+// Diff returns the distance from b to a in elements of the associated type.
+//
+// Note: the distance is truncated if the addresses are not a multiple of the size apart.
+func Diff[Underlying any](a, b T[Underlying]) int {
+	size := unsafex.SizeOf[Underlying]()
+	unsafex.Assert(size != 0, "cannot compute the distance between zero-sized values")
+	return int(uintptr(a)-uintptr(b)) / int(size)
+}
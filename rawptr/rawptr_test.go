@@ -217,3 +217,169 @@ func TestAlignBackward(t *testing.T) {
 		t.Errorf("AlignBackward did not align back to the previous address, new %x, old %x", ptr, old)
 	}
 }
+
+func TestSliceConversion(t *testing.T) {
+	values := []uint16{0xAAAA, 0xBBBB, 0xCCCC}
+
+	ptr := rawptr.FromSlice(values)
+	if ptr != rawptr.From(&values[0]) {
+		t.Errorf("expected FromSlice to point to the first element, was %X", ptr)
+	}
+
+	nvals := rawptr.ToSlice[uint16](ptr, len(values))
+	if !slices.Equal(nvals, values) {
+		t.Errorf("expected ToSlice to yield the same values %v vs. %v", nvals, values)
+	}
+
+	nvals[1] = 0xDDDD
+	if values[1] != 0xDDDD {
+		t.Errorf("expected ToSlice to share memory with the original slice, value was %X", values[1])
+	}
+
+	bytes := rawptr.ToSlice[uint8](ptr, len(values)*2)
+	if bytes[2] != 0xDD || bytes[3] != 0xDD {
+		t.Errorf("expected ToSlice to reinterpret the memory as bytes, was %X", bytes)
+	}
+
+	if ptr := rawptr.FromSlice[uint16](nil); ptr != 0 {
+		t.Errorf("expected FromSlice of a nil slice to return 0, was %X", ptr)
+	}
+}
+
+func TestStringConversion(t *testing.T) {
+	const str = "hello, world"
+
+	ptr := rawptr.FromString(str)
+	if ptr.Deref() != 'h' {
+		t.Errorf("expected FromString to point to the first byte, was %q", ptr.Deref())
+	}
+
+	if s := rawptr.ToString(ptr, len(str)); s != str {
+		t.Errorf("expected ToString to yield %q, was %q", str, s)
+	}
+
+	if s := rawptr.ToString(ptr.Nth(7), 5); s != "world" {
+		t.Errorf("expected ToString of an offset pointer to yield %q, was %q", "world", s)
+	}
+}
+
+// pointer is implemented by both T and P, so the same tests can run over either.
+type pointer[Self, Underlying any] interface {
+	comparable
+	Deref() Underlying
+	Nth(index int) Self
+	Offset(amt int) Self
+	Plus(amt uintptr) Self
+	Minus(amt uintptr) Self
+	AlignedUp() Self
+	AlignedDown() Self
+	AlignTo(n uintptr) Self
+	AlignedDownTo(n uintptr) Self
+	IsAlignedTo(n uintptr) bool
+}
+
+// mutablePointer is a pointer whose mutating methods are available.
+type mutablePointer[Self any] interface {
+	*Self
+	AlignForwardTo(n uintptr)
+	AlignBackwardTo(n uintptr)
+}
+
+func TestArithmetic(t *testing.T) {
+	values := [4]uint32{1, 2, 3, 4}
+
+	t.Run("T", func(t *testing.T) {
+		testArithmetic(t, rawptr.Cast[uint32](rawptr.From(&values)), rawptr.Diff[uint32])
+	})
+
+	t.Run("P", func(t *testing.T) {
+		testArithmetic(t, rawptr.CastP[uint32](rawptr.FromP(&values)), rawptr.DiffP[uint32])
+	})
+}
+
+// testArithmetic checks the value arithmetic of a pointer to the values 1, 2, 3, and 4.
+func testArithmetic[Ptr pointer[Ptr, uint32]](t *testing.T, base Ptr, diff func(a, b Ptr) int) {
+	start := base
+
+	if ptr := base.Plus(8); ptr.Deref() != 3 || ptr != base.Nth(2) {
+		t.Errorf("expected Plus to offset by bytes, was %v", ptr)
+	}
+
+	if ptr := base.Nth(3).Minus(4); ptr.Deref() != 3 {
+		t.Errorf("expected Minus to offset by bytes, was %v", ptr)
+	}
+
+	if ptr := base.Nth(2).Offset(-8); ptr != base {
+		t.Errorf("expected Offset to accept negative offsets, was %v", ptr)
+	}
+
+	if ptr := base.Plus(5).AlignedUp(); ptr != base.Nth(2) {
+		t.Errorf("expected chained AlignedUp to be %v, was %v", base.Nth(2), ptr)
+	}
+
+	if ptr := base.Plus(7).AlignedDown(); ptr != base.Nth(1) {
+		t.Errorf("expected chained AlignedDown to be %v, was %v", base.Nth(1), ptr)
+	}
+
+	if ptr := base.Nth(1).AlignTo(4); ptr != base.Nth(1) {
+		t.Errorf("expected AlignTo of an aligned address to do nothing, was %v", ptr)
+	}
+
+	if ptr := base.Plus(1).AlignTo(4); ptr != base.Nth(1) {
+		t.Errorf("expected AlignTo to align to the next multiple of 4, was %v", ptr)
+	}
+
+	if ptr := base.Plus(7).AlignedDownTo(4); ptr != base.Nth(1) {
+		t.Errorf("expected AlignedDownTo to align to the previous multiple of 4, was %v", ptr)
+	}
+
+	if d := diff(base.Nth(3), base); d != 3 {
+		t.Errorf("expected distance of 3, was %d", d)
+	}
+
+	if d := diff(base, base.Nth(3)); d != -3 {
+		t.Errorf("expected distance of -3, was %d", d)
+	}
+
+	if base.Plus(4) != base.Nth(1) || base != start {
+		t.Errorf("expected value arithmetic to leave the original pointer unchanged")
+	}
+}
+
+func TestAlignTo(t *testing.T) {
+	buf := make([]byte, 3*4096)
+
+	t.Run("T", func(t *testing.T) {
+		testAlignTo(t, rawptr.FromSlice(buf), rawptr.Diff[byte])
+	})
+
+	t.Run("P", func(t *testing.T) {
+		testAlignTo(t, rawptr.FromP(&buf[0]), rawptr.DiffP[byte])
+	})
+}
+
+// testAlignTo checks aligning a pointer into a buffer large enough to hold two pages past any alignment.
+func testAlignTo[Ptr pointer[Ptr, byte], Mut mutablePointer[Ptr]](t *testing.T, base Ptr, diff func(a, b Ptr) int) {
+	for _, n := range []uintptr{16, 32, 64, 4096} {
+		ptr := base.Plus(1)
+		if ptr.IsAlignedTo(n) {
+			t.Errorf("IsAlignedTo(%d) returned true for an unaligned address", n)
+		}
+
+		Mut(&ptr).AlignForwardTo(n)
+		if !ptr.IsAlignedTo(n) || diff(ptr, base) <= 0 || diff(ptr, base) > int(n) {
+			t.Errorf("AlignForwardTo(%d) did not align to the next boundary, was %v", n, ptr)
+		}
+
+		next := ptr.Plus(n - 1)
+		Mut(&next).AlignBackwardTo(n)
+		if next != ptr {
+			t.Errorf("AlignBackwardTo(%d) did not align to the previous boundary, new %v, old %v", n, next, ptr)
+		}
+
+		Mut(&ptr).AlignForwardTo(n)
+		if !ptr.IsAlignedTo(n) || ptr != next {
+			t.Errorf("AlignForwardTo(%d) moved an aligned address, new %v, old %v", n, ptr, next)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package rawptr
+
+import (
+	"iter"
+
+	"github.com/judah-caruso/unsafex"
+)
+
+// Span is a raw pointer to a sequence of values with a known length, like a slice over raw memory.
+//
+// Unlike Nth, indexing a span is bounds checked using unsafex.Assert, so the checks are
+// removed when assertions are disabled with the UNSAFEX_DISABLE_ASSERT build flag.
+//
+// Note: like T, a span does not keep the memory it points to alive.
+type Span[Underlying any] struct {
+	ptr T[Underlying]
+	len int
+}
+
+// SpanOf returns a span of n values starting at the given raw pointer.
+func SpanOf[Underlying any](p T[Underlying], n int) Span[Underlying] {
+	unsafex.Assert(n >= 0, "span length %d is negative", n)
+	return Span[Underlying]{ptr: p, len: n}
+}
+
+// SpanFrom returns a span over the elements of a slice.
+func SpanFrom[Underlying any](s []Underlying) Span[Underlying] {
+	return Span[Underlying]{ptr: FromSlice(s), len: len(s)}
+}
+
+// Ptr returns the raw pointer to the first value of a span.
+func (s Span[Underlying]) Ptr() T[Underlying] {
+	return s.ptr
+}
+
+// Len returns the number of values in a span.
+func (s Span[Underlying]) Len() int {
+	return s.len
+}
+
+// At returns the value at the given index of a span.
+func (s Span[Underlying]) At(index int) Underlying {
+	return *To[Underlying](s.nth(index))
+}
+
+// Set overwrites the value at the given index of a span.
+func (s Span[Underlying]) Set(index int, value Underlying) {
+	*To[Underlying](s.nth(index)) = value
+}
+
+// Sub returns a span of the values from lo up to, but not including, hi.
+func (s Span[Underlying]) Sub(lo, hi int) Span[Underlying] {
+	unsafex.Assert(0 <= lo && lo <= hi && hi <= s.len, "span bounds out of range [%d:%d] with length %d", lo, hi, s.len)
+	return Span[Underlying]{ptr: s.ptr.Nth(lo), len: hi - lo}
+}
+
+// Slice returns a slice sharing the memory of a span.
+func (s Span[Underlying]) Slice() []Underlying {
+	return ToSlice[Underlying](s.ptr, s.len)
+}
+
+// All returns an iterator over the indices and values of a span.
+func (s Span[Underlying]) All() iter.Seq2[int, Underlying] {
+	return func(yield func(int, Underlying) bool) {
+		for i := range s.len {
+			if !yield(i, *To[Underlying](s.ptr.Nth(i))) {
+				return
+			}
+		}
+	}
+}
+
+// nth returns the raw pointer to the value at the given index, asserting it's within the span.
+func (s Span[Underlying]) nth(index int) T[Underlying] {
+	unsafex.Assert(0 <= index && index < s.len, "span index out of range [%d] with length %d", index, s.len)
+	return s.ptr.Nth(index)
+}
@@ -0,0 +1,75 @@
+package rawptr_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/judah-caruso/unsafex/rawptr"
+)
+
+func TestSpan(t *testing.T) {
+	values := []uint16{1, 2, 3, 4, 5}
+
+	span := rawptr.SpanFrom(values)
+	if span.Len() != len(values) {
+		t.Errorf("expected len of %d, got %d", len(values), span.Len())
+	}
+
+	if span.Ptr() != rawptr.From(&values[0]) {
+		t.Errorf("expected span to point to the first element")
+	}
+
+	for i := range span.Len() {
+		if span.At(i) != values[i] {
+			t.Errorf("expected value at %d to be %d, was %d", i, values[i], span.At(i))
+		}
+	}
+
+	span.Set(2, 30)
+	if values[2] != 30 {
+		t.Errorf("expected Set to modify the underlying memory, value was %d", values[2])
+	}
+
+	if !slices.Equal(span.Slice(), values) {
+		t.Errorf("expected slice to share the span's memory %v vs. %v", span.Slice(), values)
+	}
+}
+
+func TestSpanSub(t *testing.T) {
+	values := [6]uint32{0, 1, 2, 3, 4, 5}
+
+	span := rawptr.SpanOf(rawptr.Cast[uint32](rawptr.From(&values)), len(values))
+	sub := span.Sub(2, 5)
+	if sub.Len() != 3 || sub.At(0) != 2 || sub.At(2) != 4 {
+		t.Errorf("unexpected sub span %v", sub.Slice())
+	}
+
+	if empty := span.Sub(6, 6); empty.Len() != 0 || len(empty.Slice()) != 0 {
+		t.Errorf("expected empty sub span, got %v", empty.Slice())
+	}
+
+	sub.Sub(1, 2).Set(0, 30)
+	if values[3] != 30 {
+		t.Errorf("expected nested sub span to modify the underlying memory, value was %d", values[3])
+	}
+}
+
+func TestSpanAll(t *testing.T) {
+	values := []int64{10, 20, 30, 40}
+
+	var seen []int64
+	for i, v := range rawptr.SpanFrom(values).All() {
+		if v != values[i] {
+			t.Errorf("expected value at %d to be %d, was %d", i, values[i], v)
+		}
+
+		seen = append(seen, v)
+		if i == 2 {
+			break
+		}
+	}
+
+	if !slices.Equal(seen, values[:3]) {
+		t.Errorf("expected iteration to stop early, saw %v", seen)
+	}
+}
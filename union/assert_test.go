@@ -0,0 +1,42 @@
+//go:build !UNSAFEX_DISABLE_ASSERT
+
+package union_test
+
+import (
+	"testing"
+
+	"github.com/judah-caruso/unsafex/union"
+)
+
+func TestAccessBounds(t *testing.T) {
+	type Value = union.Of[struct {
+		uint64
+		*int
+	}]
+
+	var v Value
+	union.Set[uint64](&v, 10)
+
+	cases := []struct {
+		name string
+		fn   func()
+	}{
+		{"Get", func() { union.Get[[2]uint64](v) }},
+		{"Ptr", func() { union.Ptr[[2]uint64](&v)[1] = 0xDEAD_BEEF }},
+	}
+
+	for _, c := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected a type larger than the active member to panic", c.name)
+				}
+			}()
+
+			c.fn()
+		}()
+	}
+
+	// Reading a smaller type stays within the active member.
+	_ = union.Get[uint32](v)
+}
@@ -0,0 +1,168 @@
+package union
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"sync/atomic"
+)
+
+// AtomicOf is a union that can be loaded and replaced atomically, allowing it to be shared between goroutines
+// without locks. The zero value is an uninitialized union:
+//
+//	type members = struct {
+//		int32
+//		float32
+//	}
+//
+//	var shared union.AtomicOf[members]
+//
+//	var u union.Of[members]
+//	union.Set(&u, float32(0.5))
+//	shared.Store(u)
+//
+// When every member is at most 7 bytes and contains no pointers, the tag and active member are packed
+// into a single 64-bit word and no operation allocates. Any other union, including those that need 16
+// bytes (e.g. a member of 8 bytes plus its tag), is stored as an immutable copy behind an [atomic.Pointer]
+// since sync/atomic has no 128-bit operations. Each Store, Swap, and CompareAndSwap then allocates the copy.
+//
+// An AtomicOf must not be copied after first use.
+type AtomicOf[T anystruct] struct {
+	word atomic.Uint64         // Packed tag and active member, used when every member fits.
+	box  atomic.Pointer[Of[T]] // Copy of the union, used otherwise. Nil when uninitialized.
+}
+
+// Load atomically returns the union.
+func (a *AtomicOf[T]) Load() Of[T] {
+	l := layoutOf[T]()
+	if l.packed {
+		return unpackAtomic[T](l, a.word.Load())
+	}
+
+	return unboxAtomic(a.box.Load())
+}
+
+// Store atomically replaces the union.
+func (a *AtomicOf[T]) Store(u Of[T]) {
+	l := layoutOf[T]()
+	if l.packed {
+		a.word.Store(packAtomic(l, u))
+		return
+	}
+
+	a.box.Store(boxAtomic(u))
+}
+
+// Swap atomically replaces the union and returns its previous value.
+func (a *AtomicOf[T]) Swap(u Of[T]) Of[T] {
+	l := layoutOf[T]()
+	if l.packed {
+		return unpackAtomic[T](l, a.word.Swap(packAtomic(l, u)))
+	}
+
+	return unboxAtomic(a.box.Swap(boxAtomic(u)))
+}
+
+// CompareAndSwap atomically replaces the union with new if it's currently old, returning true if it was replaced.
+//
+// Unions are compared by the bit pattern of their active member rather than ==, ignoring padding. So
+// a NaN matches an identical NaN, 0.0 and -0.0 don't match, and pointers, strings, slices, and
+// interfaces only match if they refer to the same memory.
+func (a *AtomicOf[T]) CompareAndSwap(old, new Of[T]) bool {
+	l := layoutOf[T]()
+	if l.packed {
+		return a.word.CompareAndSwap(packAtomic(l, old), packAtomic(l, new))
+	}
+
+	next := boxAtomic(new)
+	for {
+		current := a.box.Load()
+		if !sameBits(l, cmp.Or(current, &Of[T]{}), &old) {
+			return false
+		}
+
+		if a.box.CompareAndSwap(current, next) {
+			return true
+		}
+	}
+}
+
+// atomicTagShift is the position of the tag within a packed union, the remaining bytes hold the active member.
+const atomicTagShift = 56
+
+// packAtomic packs the tag and active member of a union into a single word, leaving padding as zero
+// so unions holding the same member always pack to the same word.
+func packAtomic[T anystruct](l *layout, u Of[T]) uint64 {
+	if u.tag == 0 {
+		return 0
+	}
+
+	var buf [8]byte
+	src := u.bytes(l.fields[u.tag-1])
+	for _, r := range l.dataOf(u.tag) {
+		for i := range r.count {
+			start := r.start + i*r.stride
+			copy(buf[start:start+r.size], src[start:start+r.size])
+		}
+	}
+
+	return binary.LittleEndian.Uint64(buf[:]) | uint64(u.tag)<<atomicTagShift
+}
+
+// unpackAtomic returns the union packed by packAtomic.
+func unpackAtomic[T anystruct](l *layout, x uint64) Of[T] {
+	var u Of[T]
+	tag := uint8(x >> atomicTagShift)
+	if tag == 0 {
+		return u
+	}
+
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], x)
+
+	copy(u.bytes(l.fields[tag-1]), buf[:])
+	u.tag = tag
+	return u
+}
+
+// boxAtomic returns a copy of a union to be stored behind a pointer, or nil if it's uninitialized.
+func boxAtomic[T anystruct](u Of[T]) *Of[T] {
+	if u.tag == 0 {
+		return nil
+	}
+
+	return &u
+}
+
+// unboxAtomic returns the union boxed by boxAtomic.
+func unboxAtomic[T anystruct](p *Of[T]) Of[T] {
+	if p == nil {
+		return Of[T]{}
+	}
+
+	return *p
+}
+
+// sameBits returns if two unions hold the same member with the same bit pattern, ignoring padding.
+func sameBits[T anystruct](l *layout, a, b *Of[T]) bool {
+	if a.tag != b.tag {
+		return false
+	}
+
+	if a.tag == 0 {
+		return true
+	}
+
+	field := l.fields[a.tag-1]
+	x, y := a.bytes(field), b.bytes(field)
+	for _, r := range l.dataOf(a.tag) {
+		for i := range r.count {
+			start := r.start + i*r.stride
+			if !bytes.Equal(x[start:start+r.size], y[start:start+r.size]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
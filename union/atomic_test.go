@@ -0,0 +1,257 @@
+package union_test
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/judah-caruso/unsafex/union"
+)
+
+type atomicMembers = struct {
+	int32
+	float32
+	uint8
+}
+
+func TestAtomicLoadStore(t *testing.T) {
+	var a union.AtomicOf[atomicMembers]
+	if !a.Load().IsEmpty() {
+		t.Errorf("expected zero value to be uninitialized")
+	}
+
+	var u union.Of[atomicMembers]
+	union.Set(&u, float32(-1.5))
+	a.Store(u)
+
+	loaded := a.Load()
+	if !union.Is[float32](loaded) || union.Get[float32](loaded) != -1.5 {
+		t.Errorf("expected stored union to be loaded: %v", loaded)
+	}
+
+	union.Set(&u, uint8(200))
+	if old := a.Swap(u); union.Get[float32](old) != -1.5 {
+		t.Errorf("expected Swap to return the previous union: %v", old)
+	}
+
+	if !union.Is[uint8](a.Load()) {
+		t.Errorf("expected Swap to replace the union: %v", a.Load())
+	}
+
+	a.Store(union.Of[atomicMembers]{})
+	if !a.Load().IsEmpty() {
+		t.Errorf("expected storing an uninitialized union to reset it")
+	}
+}
+
+func TestAtomicCompareAndSwap(t *testing.T) {
+	var a union.AtomicOf[atomicMembers]
+	var i, f, nan union.Of[atomicMembers]
+	union.Set(&i, int32(0))
+	union.Set(&f, float32(0))
+	union.Set(&nan, float32(math.NaN()))
+
+	if !a.CompareAndSwap(union.Of[atomicMembers]{}, i) {
+		t.Errorf("expected swap from the zero value to succeed")
+	}
+
+	if a.CompareAndSwap(f, nan) {
+		t.Errorf("expected members with the same bits but different tags to differ")
+	}
+
+	if !a.CompareAndSwap(i, nan) || !a.CompareAndSwap(nan, f) {
+		t.Errorf("expected members to be compared by bit pattern")
+	}
+}
+
+func TestAtomicPadding(t *testing.T) {
+	type (
+		small struct {
+			A int8
+			B int16
+		}
+		large struct {
+			A int8
+			B int64
+		}
+	)
+
+	testAtomicPadding[struct {
+		small
+		uint8
+	}](t, small{A: 1, B: 2}, 1)
+
+	testAtomicPadding[struct {
+		large
+		uint8
+	}](t, large{A: 1, B: 2}, 1)
+
+	testAtomicPadding[struct {
+		array [64]small
+		uint8
+	}](t, [64]small{63: {A: 1, B: 2}}, 63*4+1)
+
+	testAtomicPadding[struct {
+		nested [4][16]small
+		uint8
+	}](t, [4][16]small{3: {15: {A: 1, B: 2}}}, (3*16+15)*4+1)
+}
+
+// testAtomicPadding checks that CompareAndSwap ignores the padding byte of value at the given offset,
+// which == also ignores, but not the data byte before it.
+func testAtomicPadding[T, P comparable](t *testing.T, value P, padding uintptr) {
+	t.Helper()
+
+	var clean, dirty union.Of[T]
+	union.Set(&clean, value)
+	dirty = clean
+	*(*byte)(unsafe.Add(unsafe.Pointer(union.Ptr[P](&dirty)), padding)) = 0xFF
+
+	if union.Get[P](dirty) != union.Get[P](clean) {
+		t.Fatalf("%T: expected padding to be ignored by ==", value)
+	}
+
+	var a union.AtomicOf[T]
+	a.Store(dirty)
+	if !a.CompareAndSwap(clean, union.Of[T]{}) {
+		t.Errorf("%T: expected padding to be ignored when comparing", value)
+	}
+
+	*(*byte)(unsafe.Add(unsafe.Pointer(union.Ptr[P](&dirty)), padding-1)) = 0xFF
+	a.Store(dirty)
+	if a.CompareAndSwap(clean, union.Of[T]{}) {
+		t.Errorf("%T: expected data next to padding to be compared", value)
+	}
+}
+
+func TestAtomicLarge(t *testing.T) {
+	type members = struct {
+		int64
+		float64
+		*int
+	}
+
+	var a union.AtomicOf[members]
+	if !a.Load().IsEmpty() {
+		t.Errorf("expected zero value to be uninitialized")
+	}
+
+	x, y := 1, 1
+	var i, p, q, nan, f union.Of[members]
+	union.Set(&i, int64(math.MaxInt64))
+	union.Set(&p, &x)
+	union.Set(&q, &y)
+	union.Set(&nan, math.NaN())
+	union.Set(&f, math.Float64frombits(uint64(math.MaxInt64)))
+
+	a.Store(i)
+	if loaded := a.Load(); union.Get[int64](loaded) != math.MaxInt64 {
+		t.Errorf("expected stored union to be loaded: %v", loaded)
+	}
+
+	if a.CompareAndSwap(f, p) {
+		t.Errorf("expected members with the same bits but different tags to differ")
+	}
+
+	if !a.CompareAndSwap(i, p) || a.CompareAndSwap(q, nan) {
+		t.Errorf("expected pointers to be compared by address")
+	}
+
+	if old := a.Swap(nan); union.Get[*int](old) != &x {
+		t.Errorf("expected Swap to return the previous union: %v", old)
+	}
+
+	if !a.CompareAndSwap(nan, union.Of[members]{}) || !a.Load().IsEmpty() {
+		t.Errorf("expected members to be compared by bit pattern")
+	}
+}
+
+func TestAtomicConcurrent(t *testing.T) {
+	t.Run("packed", testAtomicConcurrent[atomicMembers])
+	t.Run("boxed", testAtomicConcurrent[struct {
+		int32
+		float32
+		int64
+	}])
+}
+
+// testAtomicConcurrent increments a counter by CAS while flipping between members, so a torn
+// read of the tag and value would produce a union that fails to convert.
+func testAtomicConcurrent[T any](t *testing.T) {
+	const (
+		goroutines = 8
+		iterations = 1000
+	)
+
+	var (
+		a  union.AtomicOf[T]
+		wg sync.WaitGroup
+	)
+
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range iterations {
+				for {
+					old := a.Load()
+
+					var n int32
+					switch {
+					case union.Is[int32](old):
+						n = union.Get[int32](old)
+					case union.Is[float32](old):
+						n = int32(union.Get[float32](old))
+					case !old.IsEmpty():
+						t.Errorf("unexpected member: %v", old)
+						return
+					}
+
+					var next union.Of[T]
+					if n%2 == 0 {
+						union.Set(&next, float32(n+1))
+					} else {
+						union.Set(&next, n+1)
+					}
+
+					if a.CompareAndSwap(old, next) {
+						break
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	final := a.Load()
+	if !union.Is[int32](final) || union.Get[int32](final) != goroutines*iterations {
+		t.Errorf("expected %d increments, got %v", goroutines*iterations, final)
+	}
+}
+
+func TestAtomicLayoutIsLazy(t *testing.T) {
+	type element struct {
+		A int8
+		B int32
+	}
+
+	type Value = union.Of[struct {
+		int64
+		array [1 << 16]element
+	}]
+
+	u := new(Value)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	union.Set(u, int64(1))
+	runtime.ReadMemStats(&after)
+
+	// The padding of every element is only needed by AtomicOf, so it must not be computed for other unions.
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 64<<10 {
+		t.Errorf("expected the first Set to allocate little, allocated %d bytes", allocated)
+	}
+}
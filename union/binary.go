@@ -0,0 +1,224 @@
+package union
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+var (
+	ErrPointerMember   = errors.New("member contains pointers")
+	ErrInvalidEncoding = errors.New("invalid union encoding")
+)
+
+// MarshalBinary implements [encoding.BinaryMarshaler].
+//
+// See [Of.AppendBinary] for details about the encoding.
+func (u Of[T]) MarshalBinary() ([]byte, error) {
+	return u.AppendBinary(nil)
+}
+
+// AppendBinary implements [encoding.BinaryAppender].
+//
+// A union is encoded as a varint tag (the index of the active member + 1, or 0 if
+// uninitialized) followed by the value of the active member. Values are encoded field by field
+// in little-endian order without padding, so the encoding is the same on every architecture:
+//
+//   - Booleans are a single byte of 0 or 1.
+//   - Sized numbers use their size, while int, uint, and uintptr always use 8 bytes.
+//   - Complex numbers are their real part followed by their imaginary part.
+//   - Arrays and structs are their elements or fields in order, skipping blank fields.
+//
+// Members containing pointers cannot be encoded and return [ErrPointerMember].
+func (u Of[T]) AppendBinary(b []byte) ([]byte, error) {
+	b = binary.AppendUvarint(b, uint64(u.tag))
+	if u.tag == 0 {
+		return b, nil
+	}
+
+	field := getInternalFields(u)[u.tag-1]
+	if hasPointers(field.Type) {
+		return nil, fmt.Errorf("%s - %w", field.Type, ErrPointerMember)
+	}
+
+	return appendFixed(b, field.Type, u.slot(field)), nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+//
+// The data is expected to be in the format described by [Of.AppendBinary].
+// The union is left unchanged if the data is invalid.
+func (u *Of[T]) UnmarshalBinary(data []byte) error {
+	tag, n := binary.Uvarint(data)
+	if n <= 0 {
+		return fmt.Errorf("tag - %w", ErrInvalidEncoding)
+	}
+
+	data = data[n:]
+	if tag == 0 {
+		if len(data) != 0 {
+			return fmt.Errorf("uninitialized union with data - %w", ErrInvalidEncoding)
+		}
+
+		u.Reset()
+		return nil
+	}
+
+	fields := getInternalFields(*u)
+	if tag > uint64(len(fields)) || tag > math.MaxUint8 {
+		return fmt.Errorf("tag %d - %w", tag, ErrInvalidType)
+	}
+
+	field := fields[tag-1]
+	if hasPointers(field.Type) {
+		return fmt.Errorf("%s - %w", field.Type, ErrPointerMember)
+	}
+
+	// Decode into a temporary so invalid data doesn't leave the union partially overwritten.
+	value := reflect.New(field.Type)
+	rest, err := readFixed(data, field.Type, value.UnsafePointer())
+	if err != nil {
+		return err
+	}
+
+	if len(rest) != 0 {
+		return fmt.Errorf("%s followed by %d extra bytes - %w", field.Type, len(rest), ErrInvalidEncoding)
+	}
+
+	u.Reset()
+	u.member(uint8(tag)).Set(value.Elem())
+	u.tag = uint8(tag)
+	return nil
+}
+
+// appendFixed appends the encoding of the pointer-free value of type t at p to b.
+func appendFixed(b []byte, t reflect.Type, p unsafe.Pointer) []byte {
+	le := binary.LittleEndian
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int8, reflect.Uint8:
+		return append(b, *(*uint8)(p))
+	case reflect.Int16, reflect.Uint16:
+		return le.AppendUint16(b, *(*uint16)(p))
+	case reflect.Int32, reflect.Uint32, reflect.Float32:
+		return le.AppendUint32(b, *(*uint32)(p))
+	case reflect.Int64, reflect.Uint64, reflect.Float64:
+		return le.AppendUint64(b, *(*uint64)(p))
+	case reflect.Int:
+		return le.AppendUint64(b, uint64(*(*int)(p)))
+	case reflect.Uint:
+		return le.AppendUint64(b, uint64(*(*uint)(p)))
+	case reflect.Uintptr:
+		return le.AppendUint64(b, uint64(*(*uintptr)(p)))
+	case reflect.Complex64:
+		c := (*[2]uint32)(p)
+		return le.AppendUint32(le.AppendUint32(b, c[0]), c[1])
+	case reflect.Complex128:
+		c := (*[2]uint64)(p)
+		return le.AppendUint64(le.AppendUint64(b, c[0]), c[1])
+	case reflect.Array:
+		elem := t.Elem()
+		for i := range t.Len() {
+			b = appendFixed(b, elem, unsafe.Add(p, uintptr(i)*elem.Size()))
+		}
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if field := t.Field(i); field.Name != "_" {
+				b = appendFixed(b, field.Type, unsafe.Add(p, field.Offset))
+			}
+		}
+	}
+
+	return b
+}
+
+// readFixed decodes a pointer-free value of type t from data into p, returning the remaining data.
+func readFixed(data []byte, t reflect.Type, p unsafe.Pointer) ([]byte, error) {
+	size := 0
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int8, reflect.Uint8:
+		size = 1
+	case reflect.Int16, reflect.Uint16:
+		size = 2
+	case reflect.Int32, reflect.Uint32, reflect.Float32:
+		size = 4
+	case reflect.Int64, reflect.Uint64, reflect.Float64, reflect.Int, reflect.Uint, reflect.Uintptr, reflect.Complex64:
+		size = 8
+	case reflect.Complex128:
+		size = 16
+	case reflect.Array:
+		elem := t.Elem()
+		for i := range t.Len() {
+			var err error
+			if data, err = readFixed(data, elem, unsafe.Add(p, uintptr(i)*elem.Size())); err != nil {
+				return nil, err
+			}
+		}
+
+		return data, nil
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if field := t.Field(i); field.Name != "_" {
+				var err error
+				if data, err = readFixed(data, field.Type, unsafe.Add(p, field.Offset)); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		return data, nil
+	}
+
+	if len(data) < size {
+		return nil, fmt.Errorf("%s expected %d bytes, got %d - %w", t, size, len(data), ErrInvalidEncoding)
+	}
+
+	le := binary.LittleEndian
+	switch t.Kind() {
+	case reflect.Bool:
+		if data[0] > 1 {
+			return nil, fmt.Errorf("%s with value %d - %w", t, data[0], ErrInvalidEncoding)
+		}
+
+		*(*uint8)(p) = data[0]
+	case reflect.Int8, reflect.Uint8:
+		*(*uint8)(p) = data[0]
+	case reflect.Int16, reflect.Uint16:
+		*(*uint16)(p) = le.Uint16(data)
+	case reflect.Int32, reflect.Uint32, reflect.Float32:
+		*(*uint32)(p) = le.Uint32(data)
+	case reflect.Int64, reflect.Uint64, reflect.Float64:
+		*(*uint64)(p) = le.Uint64(data)
+	case reflect.Int:
+		x := int64(le.Uint64(data))
+		if int64(int(x)) != x {
+			return nil, fmt.Errorf("%s cannot hold %d - %w", t, x, ErrInvalidEncoding)
+		}
+
+		*(*int)(p) = int(x)
+	case reflect.Uint:
+		x := le.Uint64(data)
+		if uint64(uint(x)) != x {
+			return nil, fmt.Errorf("%s cannot hold %d - %w", t, x, ErrInvalidEncoding)
+		}
+
+		*(*uint)(p) = uint(x)
+	case reflect.Uintptr:
+		x := le.Uint64(data)
+		if uint64(uintptr(x)) != x {
+			return nil, fmt.Errorf("%s cannot hold %d - %w", t, x, ErrInvalidEncoding)
+		}
+
+		*(*uintptr)(p) = uintptr(x)
+	case reflect.Complex64:
+		c := (*[2]uint32)(p)
+		c[0], c[1] = le.Uint32(data), le.Uint32(data[4:])
+	case reflect.Complex128:
+		c := (*[2]uint64)(p)
+		c[0], c[1] = le.Uint64(data), le.Uint64(data[8:])
+	}
+
+	return data[size:], nil
+}
@@ -0,0 +1,135 @@
+package union_test
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"testing"
+
+	"github.com/judah-caruso/unsafex/union"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	type (
+		point struct{ X, Y int32 }
+		Value = union.Of[struct {
+			uint8
+			point
+			float64
+			*int
+		}]
+	)
+
+	var (
+		_ encoding.BinaryMarshaler   = Value{}
+		_ encoding.BinaryUnmarshaler = &Value{}
+	)
+
+	var v Value
+	data, err := v.MarshalBinary()
+	if err != nil || !bytes.Equal(data, []byte{0}) {
+		t.Errorf("expected uninitialized union to encode as a zero tag, was %v (%v)", data, err)
+	}
+
+	union.Set(&v, point{X: 10, Y: -20})
+	data, err = v.AppendBinary([]byte{0xFF})
+	if err != nil {
+		t.Fatalf("failed to encode union: %s", err)
+	}
+
+	if len(data) != 10 || data[0] != 0xFF || data[1] != 2 {
+		t.Errorf("unexpected encoding for union: %v", data)
+	}
+
+	var decoded Value
+	union.Set[uint8](&decoded, 1)
+	if err := decoded.UnmarshalBinary(data[1:]); err != nil {
+		t.Fatalf("failed to decode union: %s", err)
+	}
+
+	if p, err := union.GetSafe[point](decoded); err != nil || p.X != 10 || p.Y != -20 {
+		t.Errorf("decoded union was incorrect: %+v (%v)", p, err)
+	}
+
+	x := 10
+	union.Set(&v, &x)
+	if _, err := v.MarshalBinary(); !errors.Is(err, union.ErrPointerMember) {
+		t.Errorf("expected pointer member to be rejected: %v", err)
+	}
+
+	if err := decoded.UnmarshalBinary([]byte{4, 0, 0, 0, 0, 0, 0, 0, 0}); !errors.Is(err, union.ErrPointerMember) {
+		t.Errorf("expected pointer member to be rejected while decoding: %v", err)
+	}
+
+	if err := decoded.UnmarshalBinary([]byte{5}); !errors.Is(err, union.ErrInvalidType) {
+		t.Errorf("expected out of range tag to be rejected: %v", err)
+	}
+
+	if err := decoded.UnmarshalBinary([]byte{3, 0}); !errors.Is(err, union.ErrInvalidEncoding) {
+		t.Errorf("expected truncated member to be rejected: %v", err)
+	}
+
+	if err := decoded.UnmarshalBinary([]byte{0}); err != nil || union.Is[point](decoded) {
+		t.Errorf("expected zero tag to reset the union: %s (%v)", decoded.String(), err)
+	}
+}
+
+func TestBinaryPortable(t *testing.T) {
+	type (
+		record struct {
+			Flag  bool
+			_     [3]byte
+			Count int
+			Ratio float32
+			Small [2]uint16
+			Wave  complex64
+		}
+		Value = union.Of[struct {
+			int16
+			record
+		}]
+	)
+
+	var v Value
+	union.Set(&v, record{Flag: true, Count: -2, Ratio: 1, Small: [2]uint16{0x0102, 0x0304}, Wave: complex(1, -1)})
+
+	data, err := v.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to encode union: %s", err)
+	}
+
+	// Padding and blank fields are skipped, and every number is little-endian.
+	expected := []byte{
+		2,
+		1,
+		0xFE, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+		0x00, 0x00, 0x80, 0x3F,
+		0x02, 0x01, 0x04, 0x03,
+		0x00, 0x00, 0x80, 0x3F, 0x00, 0x00, 0x80, 0xBF,
+	}
+	if !bytes.Equal(data, expected) {
+		t.Errorf("unexpected encoding for union:\n%X\n%X", data, expected)
+	}
+
+	var decoded Value
+	if err := decoded.UnmarshalBinary(data); err != nil || !union.Equal(v, decoded) {
+		t.Errorf("expected union to round trip, got %+v (%v)", decoded, err)
+	}
+
+	union.Set[int16](&decoded, 7)
+	invalid := [][]byte{
+		append(bytes.Clone(expected[:1]), 2),
+		append(bytes.Clone(expected), 0),
+		expected[:len(expected)-1],
+	}
+
+	for _, data := range invalid {
+		if err := decoded.UnmarshalBinary(data); !errors.Is(err, union.ErrInvalidEncoding) {
+			t.Errorf("expected %X to be rejected: %v", data, err)
+		}
+	}
+
+	if union.Get[int16](decoded) != 7 {
+		t.Errorf("expected invalid data to leave the union unchanged: %+v", decoded)
+	}
+}
@@ -0,0 +1,123 @@
+package union
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// Clone returns an independent copy of a union.
+//
+// Clone is equivalent to plain assignment: the union's storage is copied, so setting a member
+// of the copy doesn't affect the original. However, members that reference memory (pointers,
+// slices, maps, etc.) still share it with the original. Use [DeepClone] to copy that memory as well.
+func Clone[T anystruct, U Union[T]](u U) U {
+	return u
+}
+
+// DeepClone returns a copy of a union where any memory referenced by the active member is also copied.
+//
+// Pointers, slices, maps, and interfaces are copied recursively, preserving cycles and
+// shared pointers. Strings, channels, functions, and unsafe pointers are shared as-is.
+// Any other fields of a named union type are copied as with plain assignment.
+func DeepClone[T anystruct, U Union[T], P interface {
+	*U
+	MutableUnion[T]
+}](u U) U {
+	clone := u
+	src := u.value()
+	if src.tag == 0 {
+		return clone
+	}
+
+	field := layoutOf[T]().fields[src.tag-1]
+	deepCopy(P(&clone).locate().member(field), src.member(src.tag), make(map[seenKey]reflect.Value))
+	return clone
+}
+
+// seenKey identifies memory that has already been copied.
+type seenKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// deepCopy recursively copies src into dst. Both values are expected to be addressable.
+func deepCopy(dst, src reflect.Value, seen map[seenKey]reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+
+		key := seenKey{addr: src.Pointer(), typ: src.Type()}
+		if ptr, ok := seen[key]; ok {
+			dst.Set(ptr)
+			return
+		}
+
+		ptr := reflect.New(src.Type().Elem())
+		seen[key] = ptr
+		deepCopy(ptr.Elem(), src.Elem(), seen)
+		dst.Set(ptr)
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+
+		slice := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		for i := range src.Len() {
+			deepCopy(slice.Index(i), src.Index(i), seen)
+		}
+		dst.Set(slice)
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			key := reflect.New(src.Type().Key()).Elem()
+			deepCopy(key, addressable(iter.Key()), seen)
+
+			value := reflect.New(src.Type().Elem()).Elem()
+			deepCopy(value, addressable(iter.Value()), seen)
+
+			m.SetMapIndex(key, value)
+		}
+		dst.Set(m)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+
+		value := reflect.New(src.Elem().Type()).Elem()
+		deepCopy(value, addressable(src.Elem()), seen)
+		dst.Set(value)
+	case reflect.Array:
+		for i := range src.Len() {
+			deepCopy(dst.Index(i), src.Index(i), seen)
+		}
+	case reflect.Struct:
+		for i := range src.NumField() {
+			deepCopy(exported(dst.Field(i)), exported(src.Field(i)), seen)
+		}
+	default:
+		dst.Set(src)
+	}
+}
+
+// addressable returns an addressable copy of v.
+func addressable(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+// exported returns v without the restrictions of being obtained through an unexported field.
+func exported(v reflect.Value) reflect.Value {
+	if v.CanSet() {
+		return v
+	}
+
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
@@ -0,0 +1,103 @@
+package union_test
+
+import (
+	"testing"
+
+	"github.com/judah-caruso/unsafex/union"
+)
+
+func TestCloneAliasing(t *testing.T) {
+	type Value = union.Of[struct {
+		string
+		*int64
+	}]
+
+	x := int64(10)
+
+	var a Value
+	union.Set(&a, &x)
+
+	// Assignment copies the union itself, so setting a member of the copy doesn't change the original.
+	b := a
+	union.Set(&b, "hello")
+	if !union.Is[*int64](a) {
+		t.Errorf("setting a member of a copy changed the original: %s", a.String())
+	}
+
+	// However, memory referenced by members is still shared.
+	c := union.Clone(a)
+	*union.Get[*int64](c) = 30
+	if x != 30 {
+		t.Errorf("expected Clone to share the memory referenced by members, was %d", x)
+	}
+
+	d := union.DeepClone(a)
+	*union.Get[*int64](d) = 40
+	if x != 30 {
+		t.Errorf("expected DeepClone to copy the memory referenced by members, was %d", x)
+	}
+
+	if v := *union.Get[*int64](d); v != 40 {
+		t.Errorf("deep clone had an incorrect value: %d", v)
+	}
+}
+
+func TestDeepClone(t *testing.T) {
+	type (
+		node struct {
+			name  string
+			next  *node
+			tags  []string
+			attrs map[string]any
+			expr  expr
+		}
+		Value = union.Of[struct {
+			*node
+			int
+		}]
+	)
+
+	var lhs, rhs expr
+	union.Set(&lhs, intExpr(1))
+	union.Set(&rhs, intExpr(2))
+
+	n := &node{
+		name:  "root",
+		tags:  []string{"a", "b"},
+		attrs: map[string]any{"size": []int{1, 2}},
+	}
+	n.next = n
+	union.Set(&n.expr, binaryExpr{Op: "+", Lhs: &lhs, Rhs: &rhs})
+
+	var v Value
+	union.Set(&v, n)
+
+	if !union.DeepClone(Value{}).IsEmpty() {
+		t.Error("expected deep clone of an uninitialized union to be uninitialized")
+	}
+
+	clone := union.Get[*node](union.DeepClone(v))
+	if clone == n {
+		t.Fatal("DeepClone did not copy pointer member")
+	}
+
+	if clone.next != clone {
+		t.Error("DeepClone did not preserve the cycle")
+	}
+
+	clone.tags[0] = "c"
+	clone.attrs["size"].([]int)[0] = 10
+	*union.Get[binaryExpr](clone.expr).Lhs = rhs
+
+	if n.name != clone.name || n.tags[0] != "a" || n.attrs["size"].([]int)[0] != 1 {
+		t.Errorf("modifying the deep clone changed the original: %+v", n)
+	}
+
+	if union.Get[intExpr](lhs) != 1 {
+		t.Errorf("modifying a nested union of the deep clone changed the original: %v", lhs)
+	}
+
+	if union.Get[intExpr](*union.Get[binaryExpr](clone.expr).Lhs) != 2 {
+		t.Error("nested union of the deep clone was not modified")
+	}
+}
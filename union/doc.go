@@ -1,6 +1,12 @@
-// Package union emulates a tagged union where values of different types overlap in memory.
+// Package union emulates a tagged union, a value holding exactly one of several types.
 //
-// This provides the same benefits you'd get from a C-style union, with the addition of
-// optional runtime safety checks to ensure valid use. However, this package should still
-// be used with caution as it doesn't guarantee the garbage collector won't touch stored values.
+// This provides the same type-switching you'd get from a C-style tagged union, with the
+// addition of optional runtime safety checks to ensure valid use. Members of [Of] are kept
+// in their own slots, typed for the garbage collector, so pointers, strings, slices, and
+// interfaces stored in a union stay alive. Because of this, members do not overlap in memory
+// and a union is as large as all of its members combined. [Inline] and [Raw] provide overlapping
+// storage with the same layout as a C union for members without Go pointers.
+//
+// This package should still be used with caution as reinterpreting the active member as
+// another type can hide (or fabricate) pointers.
 package union
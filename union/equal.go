@@ -0,0 +1,116 @@
+package union
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+var ErrUncomparable = errors.New("member is not comparable")
+
+// Equal returns true if both unions are uninitialized, or have the same active member with equal values.
+//
+// Values are compared with the semantics of == for the member's type, so Equal panics
+// if the active member is not comparable (e.g. a slice, map, or func), just like
+// comparing interface values would.
+//
+// Note: unions whose members are all comparable can also be compared with == directly.
+func Equal[T anystruct, U Union[T]](x, y U) bool {
+	a, b := x.value(), y.value()
+	if a.tag != b.tag {
+		return false
+	}
+
+	if a.tag == 0 {
+		return true
+	}
+
+	av, bv := a.member(a.tag), b.member(b.tag)
+	if !av.Comparable() || !bv.Comparable() {
+		panic(fmt.Errorf("%s - %w", av.Type(), ErrUncomparable))
+	}
+
+	return av.Equal(bv)
+}
+
+// Hash returns a hash of a union for the given seed, compatible with [Equal].
+//
+// Like [maphash.Comparable], unions for which Equal returns true have the same hash
+// and Hash panics if the active member is not comparable.
+func Hash[T anystruct, U Union[T]](seed maphash.Seed, x U) uint64 {
+	u := x.value()
+
+	var h maphash.Hash
+	h.SetSeed(seed)
+	h.WriteByte(u.tag)
+	if u.tag != 0 {
+		writeHash(&h, u.member(u.tag))
+	}
+
+	return h.Sum64()
+}
+
+// writeHash writes a value to h such that values equal under == write the same bytes.
+func writeHash(h *maphash.Hash, v reflect.Value) {
+	var buf [8]byte
+	writeUint := func(x uint64) {
+		binary.LittleEndian.PutUint64(buf[:], x)
+		h.Write(buf[:])
+	}
+	writeFloat := func(f float64) {
+		if f == 0 {
+			f = 0 // Ensure +0 and -0 hash the same.
+		}
+
+		writeUint(math.Float64bits(f))
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(real(v.Complex()))
+		writeFloat(imag(v.Complex()))
+	case reflect.String:
+		writeUint(uint64(v.Len()))
+		h.WriteString(v.String())
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		writeUint(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			h.WriteByte(0)
+			return
+		}
+
+		elem := v.Elem()
+		h.WriteByte(1)
+		h.WriteString(elem.Type().String())
+		writeHash(h, elem)
+	case reflect.Array:
+		for i := range v.Len() {
+			writeHash(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			// Blank fields are ignored by ==.
+			if v.Type().Field(i).Name != "_" {
+				writeHash(h, v.Field(i))
+			}
+		}
+	default:
+		panic(fmt.Errorf("%s - %w", v.Type(), ErrUncomparable))
+	}
+}
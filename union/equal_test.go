@@ -0,0 +1,130 @@
+package union_test
+
+import (
+	"errors"
+	"hash/maphash"
+	"math"
+	"testing"
+
+	"github.com/judah-caruso/unsafex/union"
+)
+
+func TestEqual(t *testing.T) {
+	type (
+		point struct {
+			X, Y float64
+			_    int
+		}
+		Value = union.Of[struct {
+			float64
+			point
+			any
+		}]
+	)
+
+	var a, b Value
+	if !union.Equal(a, b) {
+		t.Error("expected uninitialized unions to be equal")
+	}
+
+	union.Set(&a, 1.0)
+	if union.Equal(a, b) {
+		t.Error("expected initialized and uninitialized unions to differ")
+	}
+
+	union.Set[any](&b, 1.0)
+	if union.Equal(a, b) {
+		t.Error("expected unions with different active members to differ")
+	}
+
+	union.Set(&a, math.Copysign(0, -1))
+	union.Set(&b, 0.0)
+	if !union.Equal(a, b) {
+		t.Error("expected -0 and +0 to be equal")
+	}
+
+	union.Set(&a, math.NaN())
+	union.Set(&b, math.NaN())
+	if union.Equal(a, b) {
+		t.Error("expected NaN to not equal itself")
+	}
+
+	union.Set(&a, point{X: 1, Y: 2})
+	union.Set(&b, point{X: 1, Y: 2})
+	if !union.Equal(a, b) {
+		t.Error("expected equal struct members to be equal")
+	}
+
+	union.Set[any](&a, []int{1})
+	union.Set[any](&b, []int{1})
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, union.ErrUncomparable) {
+			t.Errorf("expected Equal to panic for uncomparable values: %v", err)
+		}
+	}()
+
+	union.Equal(a, b)
+}
+
+func TestHash(t *testing.T) {
+	type Value = union.Of[struct {
+		float64
+		string
+		any
+		*int
+	}]
+
+	seed := maphash.MakeSeed()
+	hash := func(set func(v *Value)) uint64 {
+		var v Value
+		set(&v)
+		return union.Hash(seed, v)
+	}
+
+	x := 10
+	cases := [][2]func(v *Value){
+		{func(v *Value) {}, func(v *Value) {}},
+		{func(v *Value) { union.Set(v, 0.0) }, func(v *Value) { union.Set(v, math.Copysign(0, -1)) }},
+		{func(v *Value) { union.Set(v, "hello") }, func(v *Value) { union.Set(v, string([]byte("hello"))) }},
+		{func(v *Value) { union.Set[any](v, int32(10)) }, func(v *Value) { union.Set[any](v, int32(10)) }},
+		{func(v *Value) { union.Set(v, &x) }, func(v *Value) { union.Set(v, &x) }},
+	}
+
+	for i, c := range cases {
+		if hash(c[0]) != hash(c[1]) {
+			t.Errorf("expected case %d to hash the same", i)
+		}
+	}
+
+	if hash(func(v *Value) { union.Set(v, "a") }) == hash(func(v *Value) { union.Set[any](v, "a") }) {
+		t.Error("expected different members to hash differently")
+	}
+
+	if hash(func(v *Value) { union.Set[any](v, int32(1)) }) == hash(func(v *Value) { union.Set[any](v, int64(1)) }) {
+		t.Error("expected different dynamic types to hash differently")
+	}
+}
+
+func TestUnionAsMapKey(t *testing.T) {
+	type Value = union.Of[struct {
+		int64
+		string
+	}]
+
+	var a, b, c Value
+	union.Set(&a, int64(10))
+	union.Set(&b, "hello")
+	union.Set(&c, int64(20))
+	union.Set(&c, int64(10))
+
+	set := map[Value]int{}
+	for _, v := range []Value{a, b, c} {
+		set[v]++
+	}
+
+	if len(set) != 2 || set[a] != 2 || set[b] != 1 {
+		t.Errorf("expected equal unions to share a map key: %v", set)
+	}
+}
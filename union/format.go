@@ -0,0 +1,75 @@
+package union
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Format implements [fmt.Formatter], allowing unions to print their active member.
+//
+// The following verbs are supported:
+//
+//	%v   the value of the active member, or "none" if uninitialized
+//	%+v  the active member's type and value, followed by the members: union[int32: 10] { int32; uint32 }
+//	%#v  a Go-syntax representation: union.Of[struct { int32; uint32 }]{int32(10)}
+//	%s   the result of String
+//	%q   the result of String, quoted
+//
+// Flags given to %v are applied to the active member, so nested unions are formatted the same way.
+func (u Of[T]) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		switch {
+		case f.Flag('#'):
+			u.formatGo(f)
+		case f.Flag('+'):
+			u.formatVerbose(f)
+		default:
+			if u.tag == 0 {
+				fmt.Fprint(f, "none")
+				return
+			}
+
+			fmt.Fprintf(f, fmt.FormatString(f, verb), u.member(u.tag).Interface())
+		}
+	case 's':
+		fmt.Fprint(f, u.String())
+	case 'q':
+		fmt.Fprint(f, strconv.Quote(u.String()))
+	default:
+		fmt.Fprintf(f, "%%!%c(%s)", verb, u.String())
+	}
+}
+
+// formatVerbose writes the active member's type and value followed by the members of a union.
+func (u Of[T]) formatVerbose(f fmt.State) {
+	if u.tag == 0 {
+		fmt.Fprint(f, u.String())
+		return
+	}
+
+	// The members are taken from String to ensure both stay in sync.
+	str := u.String()
+	field := getInternalFields(u)[u.tag-1]
+	prefix := "union[" + field.Type.String()
+	fmt.Fprintf(f, "%s: %+v%s", prefix, u.member(u.tag).Interface(), str[len(prefix):])
+}
+
+// formatGo writes a Go-syntax representation of a union.
+func (u Of[T]) formatGo(f fmt.State) {
+	fmt.Fprintf(f, "union.Of[%s]{", reflect.TypeFor[T]())
+	if u.tag != 0 {
+		value := u.member(u.tag)
+		switch value.Kind() {
+		case reflect.Bool, reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+			// Basic values are printed without their type, so a conversion is added to keep the member unambiguous.
+			fmt.Fprintf(f, "%s(%#v)", value.Type(), value.Interface())
+		default:
+			fmt.Fprintf(f, "%#v", value.Interface())
+		}
+	}
+	fmt.Fprint(f, "}")
+}
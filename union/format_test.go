@@ -0,0 +1,70 @@
+package union_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/judah-caruso/unsafex/union"
+)
+
+func TestFormat(t *testing.T) {
+	type Value = union.Of[struct {
+		int32
+		string
+	}]
+
+	var v Value
+	cases := []struct{ format, expected string }{
+		{"%v", "none"},
+		{"%+v", "union[none] { int32; string }"},
+		{"%#v", "union.Of[struct { int32; string }]{}"},
+		{"%s", "union[none] { int32; string }"},
+	}
+
+	for _, c := range cases {
+		if str := fmt.Sprintf(c.format, v); str != c.expected {
+			t.Errorf("unexpected %s of uninitialized union: %q, expected %q", c.format, str, c.expected)
+		}
+	}
+
+	union.Set[int32](&v, 10)
+	cases = []struct{ format, expected string }{
+		{"%v", "10"},
+		{"%4v", "  10"},
+		{"%+v", "union[int32: 10] { int32; string }"},
+		{"%#v", "union.Of[struct { int32; string }]{int32(10)}"},
+		{"%s", "union[int32] { int32; string }"},
+		{"%q", `"union[int32] { int32; string }"`},
+		{"%d", "%!d(union[int32] { int32; string })"},
+	}
+
+	for _, c := range cases {
+		if str := fmt.Sprintf(c.format, v); str != c.expected {
+			t.Errorf("unexpected %s of union: %q, expected %q", c.format, str, c.expected)
+		}
+	}
+
+	union.Set(&v, "hello")
+	if str := fmt.Sprintf("%#v", v); str != `union.Of[struct { int32; string }]{string("hello")}` {
+		t.Errorf("unexpected %%#v of string member: %q", str)
+	}
+}
+
+func TestFormatNested(t *testing.T) {
+	var lhs, rhs, e expr
+	union.Set(&lhs, intExpr(10))
+	union.Set(&rhs, floatExpr(0.5))
+	union.Set(&e, binaryExpr{Op: "+", Lhs: &lhs, Rhs: &rhs})
+
+	if str := fmt.Sprintf("%v", e); str != "{+ 10 0.5}" {
+		t.Errorf("unexpected %%v of nested union: %q", str)
+	}
+
+	expected := "union[union_test.binaryExpr: {Op:+ " +
+		"Lhs:union[union_test.intExpr: 10] { union_test.binaryExpr; union_test.intExpr; union_test.floatExpr } " +
+		"Rhs:union[union_test.floatExpr: 0.5] { union_test.binaryExpr; union_test.intExpr; union_test.floatExpr }}] " +
+		"{ union_test.binaryExpr; union_test.intExpr; union_test.floatExpr }"
+	if str := fmt.Sprintf("%+v", e); str != expected {
+		t.Errorf("unexpected %%+v of nested union:\n%s\n%s", str, expected)
+	}
+}
@@ -0,0 +1,149 @@
+package union
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"unsafe"
+
+	"github.com/judah-caruso/unsafex"
+)
+
+// Inline is a tagged union whose members overlap in storage S, like a tagged union in C.
+//
+// Because Go can't compute the size of the largest member at compile time, S is the storage
+// of the union and is expected to be its largest member (or any type of the same size):
+//
+//	type Value = union.Inline[struct {
+//		int32
+//		float64
+//		[3]int16
+//	}, float64]
+//
+// Unlike [Of], which gives each member its own slot, an Inline is only as large as S plus its tag,
+// aligned to its most aligned member. The garbage collector sees its memory as S, so members and S
+// must not contain pointers.
+//
+// Accessing an Inline whose storage is smaller than its largest member, or whose members or storage
+// contain pointers, panics with [ErrInvalidDefinition] regardless of whether assertions are enabled.
+//
+// Inline implements [MutableUnion], so it's used with the same functions as [Of]:
+//
+//	var v Value
+//	union.Set(&v, float64(0.5))
+//	if union.Is[float64](v) {
+//		...
+//	}
+//
+// Like Of, the zero value is an empty union ready for use and copying an Inline copies its value.
+type Inline[T anystruct, S any] struct {
+	tag uint8 // index of the active member + 1; 0 if the union is uninitialized.
+	_   [0]T  // Aligns the storage to its most aligned member without taking up space.
+	mem S
+}
+
+// value returns the union as an [Of], allowing [Union] to be satisfied.
+func (u Inline[T, S]) value() Of[T] {
+	var v Of[T]
+	if u.tag == 0 {
+		return v
+	}
+
+	// Members are checked to be free of pointers when set, so their bytes can be copied as-is.
+	field := layoutOf[T]().fields[u.tag-1]
+	copy(v.bytes(field), unsafe.Slice((*byte)(unsafe.Pointer(&u.mem)), field.Type.Size()))
+	v.tag = u.tag
+	return v
+}
+
+// locate returns where the union keeps its members, allowing [MutableUnion] to be satisfied.
+func (u *Inline[T, S]) locate() location {
+	checkStorage[T, S]()
+	if l := layoutOf[T](); slices.Contains(l.pointers, true) {
+		panic(fmt.Errorf("%s has members with pointers, which must be stored in a union.Of - %w", reflect.TypeFor[T](), ErrInvalidDefinition))
+	}
+
+	return location{tag: &u.tag, mem: unsafe.Pointer(&u.mem), overlap: true}
+}
+
+// String returns the string representation of a union, see [Of.String].
+func (u Inline[T, S]) String() string {
+	return u.value().String()
+}
+
+// Format implements [fmt.Formatter], see [Of.Format].
+func (u Inline[T, S]) Format(f fmt.State, verb rune) {
+	u.value().Format(f, verb)
+}
+
+// Reset returns a union to its uninitialized state.
+func (u *Inline[T, S]) Reset() {
+	u.locate().reset(layoutOf[T]())
+}
+
+// IsEmpty returns true if no member is stored in the union.
+func (u Inline[T, S]) IsEmpty() bool {
+	return u.tag == 0
+}
+
+// Which returns the active member of a union, or false if the union is uninitialized.
+func (u Inline[T, S]) Which() (Member, bool) {
+	return u.value().Which()
+}
+
+// MarshalJSON implements [json.Marshaler], see [Of.MarshalJSON].
+func (u Inline[T, S]) MarshalJSON() ([]byte, error) {
+	return u.value().MarshalJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaler], see [Of.UnmarshalJSON].
+func (u *Inline[T, S]) UnmarshalJSON(data []byte) error {
+	return u.decode(data, (*Of[T]).UnmarshalJSON)
+}
+
+// MarshalText implements [encoding.TextMarshaler], see [Of.MarshalText].
+func (u Inline[T, S]) MarshalText() ([]byte, error) {
+	return u.value().MarshalText()
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler], see [Of.UnmarshalText].
+func (u *Inline[T, S]) UnmarshalText(text []byte) error {
+	return u.decode(text, (*Of[T]).UnmarshalText)
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler], see [Of.AppendBinary].
+func (u Inline[T, S]) MarshalBinary() ([]byte, error) {
+	return u.value().MarshalBinary()
+}
+
+// AppendBinary implements [encoding.BinaryAppender], see [Of.AppendBinary].
+func (u Inline[T, S]) AppendBinary(b []byte) ([]byte, error) {
+	return u.value().AppendBinary(b)
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler], see [Of.UnmarshalBinary].
+func (u *Inline[T, S]) UnmarshalBinary(data []byte) error {
+	return u.decode(data, (*Of[T]).UnmarshalBinary)
+}
+
+// decode decodes data with the given method of [Of], only replacing the union if decoding succeeds.
+func (u *Inline[T, S]) decode(data []byte, unmarshal func(*Of[T], []byte) error) error {
+	v := u.value()
+	if err := unmarshal(&v, data); err != nil {
+		return err
+	}
+
+	assign(u.locate(), v)
+	return nil
+}
+
+// checkStorage panics if S can't be used as the overlapping storage of the members of T.
+func checkStorage[T anystruct, S any]() {
+	if largest := layoutOf[T]().maxSize; unsafex.SizeOf[S]() < largest {
+		panic(fmt.Errorf("%s is smaller than the largest member of %s (%d bytes) - %w", reflect.TypeFor[S](), reflect.TypeFor[T](), largest, ErrInvalidDefinition))
+	}
+
+	if storage := reflect.TypeFor[S](); hasPointers(storage) {
+		panic(fmt.Errorf("%s contains pointers and cannot be used as storage - %w", storage, ErrInvalidDefinition))
+	}
+}
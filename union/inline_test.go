@@ -0,0 +1,171 @@
+package union_test
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"testing"
+
+	"github.com/judah-caruso/unsafex"
+	"github.com/judah-caruso/unsafex/union"
+)
+
+type (
+	inlineMembers = struct {
+		int32
+		float64
+		triple [3]int16
+	}
+	inlineValue = union.Inline[inlineMembers, float64]
+)
+
+func TestInlineLayout(t *testing.T) {
+	cases := []struct {
+		name         string
+		size, expect uintptr
+	}{
+		{"size", unsafex.SizeOf[inlineValue](), 16},
+		{"align", unsafex.AlignOf[inlineValue](), 8},
+		{"separate slots", unsafex.SizeOf[union.Of[inlineMembers]](), 32},
+		{"small members", unsafex.SizeOf[union.Inline[struct {
+			uint8
+			uint16
+		}, uint16]](), 4},
+	}
+
+	for _, c := range cases {
+		if c.size != c.expect {
+			t.Errorf("expected %s to be %d, was %d", c.name, c.expect, c.size)
+		}
+	}
+}
+
+func TestInline(t *testing.T) {
+	var v inlineValue
+	if !v.IsEmpty() || union.Is[int32](v) {
+		t.Errorf("expected zero value to be uninitialized: %v", v)
+	}
+
+	union.Set(&v, float64(-1))
+	union.Set(&v, int32(10))
+	if !union.Is[int32](v) || union.Get[int32](v) != 10 {
+		t.Errorf("expected int32 member to be active: %v", v)
+	}
+
+	if _, err := union.GetSafe[float64](v); !errors.Is(err, union.ErrInactiveMember) {
+		t.Errorf("expected inactive member to be rejected: %v", err)
+	}
+
+	// Members overlap, so setting a smaller member must clear the previous one.
+	union.Set(&v, float64(0.5))
+	*union.Ptr[float64](&v) *= 2
+	if union.Get[float64](v) != 1 {
+		t.Errorf("expected Ptr to modify the member in place: %v", v)
+	}
+
+	c := v
+	union.Set(&c, [3]int16{1, 2, 3})
+	if !union.Is[float64](v) || union.Get[[3]int16](c) != [3]int16{1, 2, 3} {
+		t.Errorf("expected copies to be independent: %v, %v", v, c)
+	}
+
+	var other inlineValue
+	union.Set(&other, float64(1))
+	if !union.Equal(v, other) || union.Equal(v, c) {
+		t.Errorf("Equal returned incorrect results for inline unions")
+	}
+
+	name := union.MustSwitch[inlineValue](
+		union.On(func(int32) string { return "int32" }),
+		union.On(func(float64) string { return "float64" }),
+		union.On(func([3]int16) string { return "triple" }),
+	)
+	if n := name.Match(c); n != "triple" {
+		t.Errorf("expected switch to match the triple member, was %s", n)
+	}
+
+	if m, ok := c.Which(); !ok || m.Name != "triple" {
+		t.Errorf("unexpected active member: %v", m)
+	}
+
+	c.Reset()
+	if !c.IsEmpty() {
+		t.Errorf("expected Reset to uninitialize the union: %v", c)
+	}
+}
+
+func TestInlineEncoding(t *testing.T) {
+	var v inlineValue
+	union.Set(&v, [3]int16{1, 2, 3})
+
+	data, err := json.Marshal(v)
+	if err != nil || string(data) != `{"type":"triple","value":[1,2,3]}` {
+		t.Fatalf("unexpected JSON for inline union: %s (%v)", data, err)
+	}
+
+	var decoded inlineValue
+	union.Set(&decoded, int32(1))
+	if err := json.Unmarshal(data, &decoded); err != nil || !union.Equal(v, decoded) {
+		t.Errorf("expected inline union to round trip, got %v (%v)", decoded, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"type":"missing"}`), &decoded); err == nil || !union.Equal(v, decoded) {
+		t.Errorf("expected invalid JSON to leave the union unchanged, got %v (%v)", decoded, err)
+	}
+
+	data, err = v.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to encode inline union: %s", err)
+	}
+
+	decoded.Reset()
+	if err := decoded.UnmarshalBinary(data); err != nil || !union.Equal(v, decoded) {
+		t.Errorf("expected inline union to round trip, got %v (%v)", decoded, err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(union.Flag(&decoded), "value", "")
+	if err := fs.Parse([]string{"-value", "float64:2.5"}); err != nil || union.Get[float64](decoded) != 2.5 {
+		t.Errorf("expected flag to set the inline union, got %v (%v)", decoded, err)
+	}
+}
+
+func TestInlineInvalid(t *testing.T) {
+	cases := []struct {
+		name string
+		set  func()
+	}{
+		{"storage too small", func() {
+			var v union.Inline[struct {
+				uint8
+				uint64
+			}, uint32]
+			union.Set(&v, uint8(1))
+		}},
+		{"storage with pointers", func() {
+			var v union.Inline[struct{ uintptr }, *int]
+			union.Set(&v, uintptr(1))
+		}},
+		{"member with pointers", func() {
+			var v union.Inline[struct {
+				uint64
+				*int
+			}, uint64]
+			union.Set(&v, uint64(1))
+		}},
+	}
+
+	// These must panic even with assertions disabled, as the storage would be out of bounds or hide pointers.
+	for _, c := range cases {
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, union.ErrInvalidDefinition) {
+					t.Errorf("%s: expected an invalid definition to panic: %v", c.name, err)
+				}
+			}()
+
+			c.set()
+		}()
+	}
+}
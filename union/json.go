@@ -0,0 +1,92 @@
+package union
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// jsonUnion is the JSON representation of an initialized union.
+type jsonUnion struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// MarshalJSON implements [json.Marshaler].
+//
+// An initialized union is encoded as an object containing the name of the active member
+// and its value:
+//
+//	{"type": "int32", "value": 10}
+//
+// Member names default to the name of the field, but can be changed with a 'union' struct tag:
+//
+//	type Value = union.Of[struct {
+//		int32   `union:"int"`
+//		float32 `union:"float"`
+//	}]
+//
+// An uninitialized union is encoded as null.
+func (u Of[T]) MarshalJSON() ([]byte, error) {
+	if u.tag == 0 {
+		return []byte("null"), nil
+	}
+
+	value, err := json.Marshal(u.member(u.tag).Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	field := getInternalFields(u)[u.tag-1]
+	return json.Marshal(jsonUnion{
+		Type:  memberName(field),
+		Value: value,
+	})
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+//
+// The object's type is used to decode the value into the matching member,
+// returning an error if it does not exist within the union.
+// Decoding null resets the union to its uninitialized state.
+func (u *Of[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		u.Reset()
+		return nil
+	}
+
+	var raw jsonUnion
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	fields := getInternalFields(*u)
+	index := memberIndex(fields, raw.Type)
+	if index < 0 {
+		return fmt.Errorf("%q - %w", raw.Type, ErrInvalidType)
+	}
+
+	value := reflect.New(fields[index].Type)
+	if err := json.Unmarshal(raw.Value, value.Interface()); err != nil {
+		return fmt.Errorf("decoding %q: %w", raw.Type, err)
+	}
+
+	tag := uint8(index + 1)
+	u.Reset()
+	u.member(tag).Set(value.Elem())
+	u.tag = tag
+	return nil
+}
+
+// memberIndex returns the index of the taggable member with the given name, or -1 if none exist.
+func memberIndex(fields []reflect.StructField, name string) int {
+	for i, field := range fields[:min(len(fields), math.MaxUint8)] {
+		if memberName(field) == name {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,80 @@
+package union_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/judah-caruso/unsafex/union"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	var lhs, rhs, sum, e expr
+	union.Set(&lhs, intExpr(10))
+	union.Set(&rhs, floatExpr(0.5))
+	union.Set(&sum, binaryExpr{Op: "+", Lhs: &lhs, Rhs: &rhs})
+	union.Set(&e, binaryExpr{Op: "-", Lhs: &sum, Rhs: &lhs})
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("failed to marshal union: %s", err)
+	}
+
+	const expected = `{"type":"binaryExpr","value":{"Op":"-",` +
+		`"Lhs":{"type":"binaryExpr","value":{"Op":"+",` +
+		`"Lhs":{"type":"intExpr","value":10},"Rhs":{"type":"floatExpr","value":0.5}}},` +
+		`"Rhs":{"type":"intExpr","value":10}}}`
+	if string(data) != expected {
+		t.Errorf("unexpected JSON for union:\n%s\n%s", data, expected)
+	}
+
+	var decoded expr
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal union: %s", err)
+	}
+
+	bin := union.Get[binaryExpr](decoded)
+	if bin.Op != "-" || !union.Is[binaryExpr](*bin.Lhs) || union.Get[intExpr](*bin.Rhs) != 10 {
+		t.Errorf("decoded union was incorrect: %+v", bin)
+	}
+
+	inner := union.Get[binaryExpr](*bin.Lhs)
+	if union.Get[intExpr](*inner.Lhs) != 10 || union.Get[floatExpr](*inner.Rhs) != 0.5 {
+		t.Errorf("decoded nested union was incorrect: %+v", inner)
+	}
+}
+
+func TestJSONTags(t *testing.T) {
+	type Value = union.Of[struct {
+		int64  `union:"int"`
+		string `union:"str"`
+	}]
+
+	var v Value
+	data, err := json.Marshal(v)
+	if err != nil || string(data) != "null" {
+		t.Errorf("expected uninitialized union to marshal as null, was %s (%v)", data, err)
+	}
+
+	union.Set(&v, "hello")
+	data, err = json.Marshal(v)
+	if err != nil || string(data) != `{"type":"str","value":"hello"}` {
+		t.Errorf("unexpected JSON for tagged member: %s (%v)", data, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"type":"int","value":20}`), &v); err != nil {
+		t.Fatalf("failed to unmarshal tagged member: %s", err)
+	}
+
+	if !union.Is[int64](v) || union.Get[int64](v) != 20 {
+		t.Errorf("unmarshaled union was incorrect: %s", v.String())
+	}
+
+	if err := json.Unmarshal([]byte(`{"type":"int64","value":20}`), &v); !errors.Is(err, union.ErrInvalidType) {
+		t.Errorf("expected unknown member name to fail: %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(`null`), &v); err != nil || union.Is[int64](v) {
+		t.Errorf("expected null to reset the union: %s (%v)", v.String(), err)
+	}
+}
@@ -0,0 +1,223 @@
+package union
+
+import (
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// layout describes the members of a union's internal type.
+//
+// Layouts are computed once per type and shared, so they must not be modified.
+type layout struct {
+	typ      reflect.Type
+	fields   []reflect.StructField
+	types    []reflect.Type // Types of the taggable members, indexed by tag-1.
+	pointers []bool         // Whether each member contains pointers.
+	maxSize  uintptr        // Size of the largest member.
+	packed   bool           // Whether every member can be packed into a word, see [AtomicOf].
+
+	dataOnce sync.Once
+	data     [][]byteRange // Bytes of each member holding data rather than padding, see dataOf.
+}
+
+// byteRange is a range of size bytes within a value at start, repeated count times every stride bytes.
+type byteRange struct {
+	start, size   uintptr
+	stride, count uintptr
+}
+
+var (
+	// layouts holds the layout of every union type accessed so far.
+	//
+	// The cache is replaced rather than modified when a layout is added, so lookups
+	// only read shared memory and never contend. New types are rare, since each union
+	// type is only added once.
+	layouts atomic.Pointer[layoutCache]
+
+	// layoutsMu serializes replacing layouts.
+	layoutsMu sync.Mutex
+)
+
+// layoutScanLimit is the number of cached layouts below which a linear scan is faster than hashing the type.
+const layoutScanLimit = 8
+
+// layoutCache maps the internal type of a union to its layout.
+type layoutCache struct {
+	list   []*layout // In the order they were added.
+	byType map[reflect.Type]*layout
+}
+
+// lookup returns the cached layout of t, or nil if it isn't cached.
+func (c *layoutCache) lookup(t reflect.Type) *layout {
+	if len(c.list) > layoutScanLimit {
+		return c.byType[t]
+	}
+
+	for _, l := range c.list {
+		if l.typ == t {
+			return l
+		}
+	}
+
+	return nil
+}
+
+// layoutOf returns the layout of the internal type T.
+func layoutOf[T anystruct]() *layout {
+	t := reflect.TypeFor[T]()
+	if cached := layouts.Load(); cached != nil {
+		if l := cached.lookup(t); l != nil {
+			return l
+		}
+	}
+
+	return loadLayout(t)
+}
+
+// loadLayout computes and caches the layout of t, returning the existing layout if it's already cached.
+//
+// It returns an empty layout if t is not a struct.
+func loadLayout(t reflect.Type) *layout {
+	layoutsMu.Lock()
+	defer layoutsMu.Unlock()
+
+	old := layouts.Load()
+	if old == nil {
+		old = &layoutCache{}
+	}
+
+	if l := old.lookup(t); l != nil {
+		return l
+	}
+
+	l := &layout{typ: t}
+	if t.Kind() == reflect.Struct {
+		for i := range t.NumField() {
+			field := t.Field(i)
+			l.fields = append(l.fields, field)
+			l.pointers = append(l.pointers, hasPointers(field.Type))
+			l.maxSize = max(l.maxSize, field.Type.Size())
+			if i < math.MaxUint8 {
+				l.types = append(l.types, field.Type)
+			}
+		}
+	}
+
+	l.packed = l.maxSize <= atomicTagShift/8 && !slices.Contains(l.pointers, true)
+
+	updated := &layoutCache{
+		list:   append(slices.Clip(old.list), l),
+		byType: maps.Clone(old.byType),
+	}
+	if updated.byType == nil {
+		updated.byType = make(map[reflect.Type]*layout)
+	}
+
+	updated.byType[t] = l
+	layouts.Store(updated)
+	return l
+}
+
+// dataOf returns the ranges of bytes holding data in the member with the given tag.
+//
+// Since only [AtomicOf] needs them, the ranges are computed on first use rather than with the layout.
+func (l *layout) dataOf(tag uint8) []byteRange {
+	l.dataOnce.Do(func() {
+		l.data = make([][]byteRange, len(l.fields))
+		for i, field := range l.fields {
+			l.data[i] = dataRanges(nil, field.Type, 0)
+		}
+	})
+
+	return l.data[tag-1]
+}
+
+// dataRanges appends the ranges of bytes holding data in a value of type t at the given offset,
+// skipping padding and blank fields since they're ignored by ==.
+func dataRanges(ranges []byteRange, t reflect.Type, offset uintptr) []byteRange {
+	switch t.Kind() {
+	case reflect.Array:
+		if t.Len() == 0 {
+			return ranges
+		}
+
+		elem := dataRanges(nil, t.Elem(), 0)
+		stride := t.Elem().Size()
+		if len(elem) == 1 && elem[0].count == 1 && elem[0].size == stride {
+			return appendRange(ranges, byteRange{start: offset, size: t.Size(), count: 1})
+		}
+
+		// Repeat each range of the element once per element, unless it's already repeated by a nested array.
+		if !slices.ContainsFunc(elem, func(r byteRange) bool { return r.count > 1 }) {
+			for _, r := range elem {
+				ranges = append(ranges, byteRange{start: offset + r.start, size: r.size, stride: stride, count: uintptr(t.Len())})
+			}
+
+			return ranges
+		}
+
+		for i := range t.Len() {
+			for _, r := range elem {
+				r.start += offset + uintptr(i)*stride
+				ranges = appendRange(ranges, r)
+			}
+		}
+
+		return ranges
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if field := t.Field(i); field.Name != "_" {
+				ranges = dataRanges(ranges, field.Type, offset+field.Offset)
+			}
+		}
+
+		return ranges
+	}
+
+	return appendRange(ranges, byteRange{start: offset, size: t.Size(), count: 1})
+}
+
+// appendRange appends r to ranges, merging it with the previous range when both are contiguous
+// and not repeated, so values without padding are a single range.
+func appendRange(ranges []byteRange, r byteRange) []byteRange {
+	if r.size == 0 {
+		return ranges
+	}
+
+	if n := len(ranges); n > 0 && r.count == 1 && ranges[n-1].count == 1 && ranges[n-1].start+ranges[n-1].size == r.start {
+		ranges[n-1].size += r.size
+		return ranges
+	}
+
+	return append(ranges, r)
+}
+
+// tag returns the tag of the member with the given type, or 0 if it isn't a member.
+//
+// Note: only the first 255 members of a union can be tagged.
+func (l *layout) tag(t reflect.Type) uint8 {
+	for i, typ := range l.types {
+		if typ == t {
+			return uint8(i + 1)
+		}
+	}
+
+	return 0
+}
+
+// clear zeroes the memory of the member with the given tag, stored at p.
+func (l *layout) clear(p unsafe.Pointer, tag uint8) {
+	field := l.fields[tag-1]
+	if !l.pointers[tag-1] {
+		clear(unsafe.Slice((*byte)(p), field.Type.Size()))
+		return
+	}
+
+	// Zeroing through reflect ensures the write barriers for any pointers in the old member are respected.
+	reflect.NewAt(field.Type, p).Elem().SetZero()
+}
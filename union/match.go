@@ -0,0 +1,135 @@
+package union
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+var (
+	ErrNonExhaustive = errors.New("member is not handled")
+	ErrDuplicateCase = errors.New("member is handled more than once")
+)
+
+// Case is a handler for a single member of a union.
+//
+// Cases are created with [On] and dispatched by [Match] or a [Switch].
+type Case[R any] struct {
+	typ    reflect.Type
+	handle func(p unsafe.Pointer) R
+}
+
+// On returns a case that calls fn with the value of the active member when its type is V.
+func On[V, R any](fn func(V) R) Case[R] {
+	return Case[R]{
+		typ: reflect.TypeFor[V](),
+		handle: func(p unsafe.Pointer) R {
+			return fn(*(*V)(p))
+		},
+	}
+}
+
+// Switch dispatches to a handler based on the active member of a union.
+//
+// Unlike [Match], a Switch is verified to handle every member of the union when
+// it's created. Declaring switches with [MustSwitch] at the package level ensures
+// missing cases are reported at init time.
+type Switch[T anystruct, R any] struct {
+	handlers []func(p unsafe.Pointer) R // indexed by member
+}
+
+// NewSwitch returns a switch over the members of U, returning an error
+// if the cases do not handle every member exactly once.
+//
+// U is expected to be a union type so the members can be inferred:
+//
+//	eval, err := union.NewSwitch[Value](
+//		union.On(func(v int32) string { ... }),
+//		union.On(func(v float32) string { ... }),
+//	)
+func NewSwitch[U Union[T], T anystruct, R any](cases ...Case[R]) (Switch[T, R], error) {
+	fields := getInternalFields(Of[T]{})
+	handlers := make([]func(p unsafe.Pointer) R, len(fields))
+
+	var errs []error
+	for _, c := range cases {
+		index := fieldIndex(fields, c.typ)
+		if index < 0 {
+			errs = append(errs, fmt.Errorf("%s - %w", c.typ, ErrInvalidType))
+			continue
+		}
+
+		if handlers[index] != nil {
+			errs = append(errs, fmt.Errorf("%s - %w", c.typ, ErrDuplicateCase))
+			continue
+		}
+
+		handlers[index] = c.handle
+	}
+
+	for i, handler := range handlers {
+		if handler == nil {
+			errs = append(errs, fmt.Errorf("%s - %w", fields[i].Type, ErrNonExhaustive))
+		}
+	}
+
+	if len(errs) != 0 {
+		return Switch[T, R]{}, errors.Join(errs...)
+	}
+
+	return Switch[T, R]{handlers: handlers}, nil
+}
+
+// MustSwitch is like [NewSwitch] but panics if the cases do not handle every member exactly once.
+func MustSwitch[U Union[T], T anystruct, R any](cases ...Case[R]) Switch[T, R] {
+	s, err := NewSwitch[U](cases...)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// Match calls the handler for the active member of a union and returns its result, panicking if the union is uninitialized.
+func (s Switch[T, R]) Match(x Union[T]) R {
+	u := x.value()
+	if u.tag == 0 {
+		panic(ErrUninitializedAccess)
+	}
+
+	field := getInternalFields(u)[u.tag-1]
+	return s.handlers[u.tag-1](u.slot(field))
+}
+
+// Match calls the case handling the active member of a union and returns its result.
+//
+// An error is returned if the union is uninitialized or no case handles the active member.
+// Use [Switch] to verify every member is handled ahead of time.
+func Match[T anystruct, R any, U Union[T]](u U, cases ...Case[R]) (R, error) {
+	var zero R
+	value := u.value()
+	if value.tag == 0 {
+		return zero, ErrUninitializedAccess
+	}
+
+	field := getInternalFields(value)[value.tag-1]
+	for _, c := range cases {
+		if c.typ == field.Type {
+			return c.handle(value.slot(field)), nil
+		}
+	}
+
+	return zero, fmt.Errorf("%s - %w", field.Type, ErrNonExhaustive)
+}
+
+// fieldIndex returns the index of the field with the given type, or -1 if none exist.
+func fieldIndex(fields []reflect.StructField, typ reflect.Type) int {
+	for i, field := range fields {
+		if field.Type == typ {
+			return i
+		}
+	}
+
+	return -1
+}
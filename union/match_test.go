@@ -0,0 +1,89 @@
+package union_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/judah-caruso/unsafex/union"
+)
+
+func TestSwitch(t *testing.T) {
+	var eval func(e expr) float64
+
+	evaluator := union.MustSwitch[expr](
+		union.On(func(e binaryExpr) float64 {
+			lhs, rhs := eval(*e.Lhs), eval(*e.Rhs)
+			switch e.Op {
+			case "+":
+				return lhs + rhs
+			case "-":
+				return lhs - rhs
+			default:
+				t.Fatalf("unknown operator %q", e.Op)
+				return 0
+			}
+		}),
+		union.On(func(e intExpr) float64 { return float64(e) }),
+		union.On(func(e floatExpr) float64 { return float64(e) }),
+	)
+	eval = func(e expr) float64 { return evaluator.Match(e) }
+
+	var lhs, rhs, sum, e expr
+	union.Set(&lhs, intExpr(10))
+	union.Set(&rhs, floatExpr(0.5))
+	union.Set(&sum, binaryExpr{Op: "+", Lhs: &lhs, Rhs: &rhs})
+	union.Set(&e, binaryExpr{Op: "-", Lhs: &sum, Rhs: &lhs})
+
+	if v := eval(e); v != 0.5 {
+		t.Errorf("expected expression to evaluate to 0.5, was %v", v)
+	}
+}
+
+func TestSwitchExhaustive(t *testing.T) {
+	_, err := union.NewSwitch[expr](
+		union.On(func(e intExpr) int { return 0 }),
+		union.On(func(e intExpr) int { return 1 }),
+		union.On(func(e string) int { return 2 }),
+	)
+
+	if !errors.Is(err, union.ErrNonExhaustive) {
+		t.Errorf("expected missing members to be reported: %v", err)
+	}
+
+	if !errors.Is(err, union.ErrDuplicateCase) {
+		t.Errorf("expected duplicate cases to be reported: %v", err)
+	}
+
+	if !errors.Is(err, union.ErrInvalidType) {
+		t.Errorf("expected non-member cases to be reported: %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustSwitch to panic for an incomplete switch")
+		}
+	}()
+
+	union.MustSwitch[expr](union.On(func(e floatExpr) int { return 0 }))
+}
+
+func TestMatch(t *testing.T) {
+	var e expr
+	if _, err := union.Match(e, union.On(func(e intExpr) int { return 0 })); !errors.Is(err, union.ErrUninitializedAccess) {
+		t.Errorf("expected Match to fail on an uninitialized union: %v", err)
+	}
+
+	union.Set(&e, floatExpr(3.14))
+
+	v, err := union.Match(e,
+		union.On(func(e intExpr) string { return "int" }),
+		union.On(func(e floatExpr) string { return "float" }),
+	)
+	if err != nil || v != "float" {
+		t.Errorf("expected Match to call the float case, got %q (%v)", v, err)
+	}
+
+	if _, err := union.Match(e, union.On(func(e intExpr) string { return "int" })); !errors.Is(err, union.ErrNonExhaustive) {
+		t.Errorf("expected Match to fail when the active member is unhandled: %v", err)
+	}
+}
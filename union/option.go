@@ -0,0 +1,97 @@
+package union
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+var ErrUnwrap = errors.New("unwrap of an empty option or unsuccessful result")
+
+// some is the member of an [Option] storing its value.
+type some[V any] struct{ value V }
+
+// Option is an optional value, built on [Of].
+//
+// The zero value is an empty option (None).
+type Option[V any] struct {
+	u Of[struct{ some[V] }]
+}
+
+// Some returns an option holding the given value.
+func Some[V any](value V) Option[V] {
+	var o Option[V]
+	Set(&o.u, some[V]{value})
+	return o
+}
+
+// None returns an empty option.
+func None[V any]() Option[V] {
+	return Option[V]{}
+}
+
+// IsSome returns true if the option holds a value.
+func (o Option[V]) IsSome() bool {
+	return !o.u.IsEmpty()
+}
+
+// IsNone returns true if the option is empty.
+func (o Option[V]) IsNone() bool {
+	return o.u.IsEmpty()
+}
+
+// Get returns the value of the option, or false if it's empty.
+func (o Option[V]) Get() (V, bool) {
+	if o.u.IsEmpty() {
+		var zero V
+		return zero, false
+	}
+
+	return Get[some[V]](o.u).value, true
+}
+
+// Unwrap returns the value of the option, panicking if it's empty.
+func (o Option[V]) Unwrap() V {
+	v, ok := o.Get()
+	if !ok {
+		panic(fmt.Errorf("%s - %w", o, ErrUnwrap))
+	}
+
+	return v
+}
+
+// UnwrapOr returns the value of the option, or fallback if it's empty.
+func (o Option[V]) UnwrapOr(fallback V) V {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+
+	return fallback
+}
+
+// All returns an iterator over the value of the option, yielding nothing if it's empty.
+func (o Option[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if v, ok := o.Get(); ok {
+			yield(v)
+		}
+	}
+}
+
+// String returns the string representation of an option: Some(value) or None.
+func (o Option[V]) String() string {
+	if v, ok := o.Get(); ok {
+		return fmt.Sprintf("Some(%v)", v)
+	}
+
+	return "None"
+}
+
+// MapOption returns an option holding the result of fn applied to the value of o, or None if o is empty.
+func MapOption[V, W any](o Option[V], fn func(V) W) Option[W] {
+	if v, ok := o.Get(); ok {
+		return Some(fn(v))
+	}
+
+	return None[W]()
+}
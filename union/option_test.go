@@ -0,0 +1,66 @@
+package union_test
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/judah-caruso/unsafex/union"
+)
+
+func TestOption(t *testing.T) {
+	some := union.Some(10)
+	none := union.None[int]()
+
+	if !some.IsSome() || some.IsNone() {
+		t.Error("expected Some to hold a value")
+	}
+
+	if none.IsSome() || !none.IsNone() {
+		t.Error("expected None to be empty")
+	}
+
+	var zero union.Option[int]
+	if !zero.IsNone() {
+		t.Error("expected zero value option to be empty")
+	}
+
+	if v, ok := some.Get(); !ok || v != 10 {
+		t.Errorf("expected Get to return 10, got %v (%v)", v, ok)
+	}
+
+	if v := some.UnwrapOr(20); v != 10 {
+		t.Errorf("expected UnwrapOr of Some to return its value, got %v", v)
+	}
+
+	if v := none.UnwrapOr(20); v != 20 {
+		t.Errorf("expected UnwrapOr of None to return the fallback, got %v", v)
+	}
+
+	str := union.MapOption(some, strconv.Itoa)
+	if str.Unwrap() != "10" || union.MapOption(none, strconv.Itoa).IsSome() {
+		t.Errorf("MapOption returned incorrect results: %s", str)
+	}
+
+	if some.String() != "Some(10)" || none.String() != "None" {
+		t.Errorf("unexpected stringification of options: %s, %s", some, none)
+	}
+
+	if values := slices.Collect(some.All()); !slices.Equal(values, []int{10}) {
+		t.Errorf("expected All of Some to yield its value, got %v", values)
+	}
+
+	if values := slices.Collect(none.All()); len(values) != 0 {
+		t.Errorf("expected All of None to yield nothing, got %v", values)
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, union.ErrUnwrap) {
+			t.Errorf("expected Unwrap of None to panic: %v", err)
+		}
+	}()
+
+	none.Unwrap()
+}
@@ -0,0 +1,60 @@
+package union
+
+import (
+	"fmt"
+	"reflect"
+	"structs"
+	"unsafe"
+)
+
+// Raw is an untagged union with the same memory layout as a C union of the members of T.
+//
+// Because Go can't compute the size of the largest member at compile time, S is the storage
+// of the union and is expected to be its largest member (or any type of the same size):
+//
+//	type Data = union.Raw[struct {
+//		uint32
+//		uint64
+//	}, uint64]
+//
+// Like C, Raw is aligned to its most aligned member and its size is S rounded up to that
+// alignment. Raw uses structs.HostLayout itself, since host layout isn't inherited by nested
+// structs, so it can be used as a field of structs.HostLayout structs passed to C or syscalls.
+//
+// Accessing a Raw whose storage is smaller than its largest member panics with [ErrInvalidDefinition],
+// regardless of whether assertions are enabled.
+//
+// Accessing a Raw whose storage contains pointers also panics with [ErrInvalidDefinition], as writing
+// other members over it would leave values the garbage collector treats as pointers.
+//
+// Unlike [Of], Raw doesn't track the active member, and the garbage collector sees its memory as S.
+// Storing Go pointers in a Raw does not keep what they point to alive, so members with pointers
+// should only refer to memory kept alive elsewhere (or not managed by Go).
+type Raw[T anystruct, S any] struct {
+	_   structs.HostLayout
+	_   [0]T // Aligns the union to its most aligned member without taking up space.
+	mem S
+}
+
+// SetRaw overwrites the memory of a raw union with the given value, panicking if V is not a member of the union.
+func SetRaw[V any, T anystruct, S any](r *Raw[T, S], value V) {
+	*PtrRaw[V](r) = value
+}
+
+// GetRaw returns the memory of a raw union interpreted as a value of type V, panicking if V is not a member of the union.
+//
+// Since raw unions are untagged, any member can be read regardless of which was last set.
+func GetRaw[V any, T anystruct, S any](r Raw[T, S]) V {
+	return *PtrRaw[V](&r)
+}
+
+// PtrRaw returns a pointer to the memory of a raw union interpreted as type V, panicking if V is not a member of the union.
+func PtrRaw[V any, T anystruct, S any](r *Raw[T, S]) *V {
+	if memberTag[V, T]() == 0 {
+		panic(fmt.Errorf("%s - %w", reflect.TypeFor[V](), ErrInvalidType))
+	}
+
+	checkStorage[T, S]()
+
+	return (*V)(unsafe.Pointer(&r.mem))
+}
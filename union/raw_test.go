@@ -0,0 +1,130 @@
+package union_test
+
+import (
+	"errors"
+	"structs"
+	"testing"
+	"unsafe"
+
+	"github.com/judah-caruso/unsafex"
+	"github.com/judah-caruso/unsafex/union"
+)
+
+func TestRawLayout(t *testing.T) {
+	type (
+		sockaddr struct {
+			_      structs.HostLayout
+			Family uint16
+			Data   [14]byte
+		}
+		sockaddrIn struct {
+			_      structs.HostLayout
+			Family uint16
+			Port   uint16
+			Addr   [4]byte
+			Zero   [8]byte
+		}
+		sockaddrIn6 struct {
+			_        structs.HostLayout
+			Family   uint16
+			Port     uint16
+			Flowinfo uint32
+			Addr     [16]byte
+			ScopeID  uint32
+		}
+		sockaddrAny = union.Raw[struct {
+			sockaddr
+			sockaddrIn
+			sockaddrIn6
+		}, sockaddrIn6]
+		triple [3]byte
+		tagged struct {
+			_    structs.HostLayout
+			Kind uint8
+			Data union.Raw[struct {
+				uint8
+				uint32
+				uint64
+			}, uint64]
+		}
+	)
+
+	cases := []struct {
+		name         string
+		size, expect uintptr
+	}{
+		{"sockaddr size", unsafex.SizeOf[sockaddrAny](), 28},
+		{"sockaddr align", unsafex.AlignOf[sockaddrAny](), 4},
+		{"tagged size", unsafex.SizeOf[tagged](), 16},
+		{"tagged data offset", unsafe.Offsetof(tagged{}.Data), 8},
+		{"padded size", unsafex.SizeOf[union.Raw[struct {
+			uint64
+			triple
+		}, triple]](), 8},
+	}
+
+	for _, c := range cases {
+		if c.size != c.expect {
+			t.Errorf("expected %s to be %d, was %d", c.name, c.expect, c.size)
+		}
+	}
+
+	var addr sockaddrAny
+	union.SetRaw(&addr, sockaddrIn{Family: 2, Port: 0x5000, Addr: [4]byte{127, 0, 0, 1}})
+
+	if family := union.GetRaw[sockaddr](addr).Family; family != 2 {
+		t.Errorf("expected members to overlap in memory, family was %d", family)
+	}
+
+	union.PtrRaw[sockaddrIn6](&addr).Port = 0x2000
+	if port := union.GetRaw[sockaddrIn](addr).Port; port != 0x2000 {
+		t.Errorf("expected modification through PtrRaw to be visible, port was %X", port)
+	}
+}
+
+func TestRawInvalidMember(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, union.ErrInvalidType) {
+			t.Errorf("expected SetRaw to panic with a non-member: %v", err)
+		}
+	}()
+
+	var r union.Raw[struct {
+		int32
+		float32
+	}, int32]
+	union.SetRaw(&r, int64(10))
+}
+
+func TestRawStorageTooSmall(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, union.ErrInvalidDefinition) {
+			t.Errorf("expected storage smaller than the largest member to panic: %v", err)
+		}
+	}()
+
+	// This must panic even with assertions disabled, as SetRaw would write out of bounds.
+	var r union.Raw[struct {
+		uint8
+		uint64
+	}, uint8]
+	union.SetRaw(&r, uint8(1))
+}
+
+func TestRawStorageWithPointers(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, union.ErrInvalidDefinition) {
+			t.Errorf("expected storage containing pointers to panic: %v", err)
+		}
+	}()
+
+	// Writing a uintptr over *int storage would leave a fake pointer for the garbage collector to scan.
+	var r union.Raw[struct {
+		uintptr
+		*int
+	}, *int]
+	union.SetRaw(&r, uintptr(0xDEAD_BEEF))
+}
@@ -0,0 +1,136 @@
+package union
+
+import (
+	"fmt"
+	"iter"
+)
+
+type (
+	// okValue is the member of a [Result] storing a successful value.
+	okValue[V any] struct{ value V }
+	// errValue is the member of a [Result] storing an error.
+	errValue[E any] struct{ err E }
+)
+
+// Result is either a successful value or an error, built on [Of].
+//
+// Results should be created with [Ok] or [Err]. The zero value is an invalid result
+// that is neither: IsOk and IsErr return false, Unwrap and UnwrapErr panic, and it's
+// printed as Invalid. [MapResult] keeps invalid results invalid.
+//
+// Like [Of], the value and error are stored in separate slots rather than overlapping,
+// so a Result is the size of V plus E. Large values or errors should be stored by pointer.
+type Result[V, E any] struct {
+	u Of[struct {
+		okValue[V]
+		errValue[E]
+	}]
+}
+
+// Ok returns a successful result holding the given value.
+func Ok[V, E any](value V) Result[V, E] {
+	var r Result[V, E]
+	Set(&r.u, okValue[V]{value})
+	return r
+}
+
+// Err returns an unsuccessful result holding the given error.
+func Err[V, E any](err E) Result[V, E] {
+	var r Result[V, E]
+	Set(&r.u, errValue[E]{err})
+	return r
+}
+
+// IsOk returns true if the result is successful.
+func (r Result[V, E]) IsOk() bool {
+	return Is[okValue[V]](r.u)
+}
+
+// IsErr returns true if the result is unsuccessful.
+func (r Result[V, E]) IsErr() bool {
+	return Is[errValue[E]](r.u)
+}
+
+// Ok returns the value of the result, or false if it's unsuccessful.
+func (r Result[V, E]) Ok() (V, bool) {
+	if !r.IsOk() {
+		var zero V
+		return zero, false
+	}
+
+	return Get[okValue[V]](r.u).value, true
+}
+
+// Err returns the error of the result, or false if it's successful.
+func (r Result[V, E]) Err() (E, bool) {
+	if !r.IsErr() {
+		var zero E
+		return zero, false
+	}
+
+	return Get[errValue[E]](r.u).err, true
+}
+
+// Unwrap returns the value of the result, panicking if it's unsuccessful.
+func (r Result[V, E]) Unwrap() V {
+	v, ok := r.Ok()
+	if !ok {
+		panic(fmt.Errorf("%s - %w", r, ErrUnwrap))
+	}
+
+	return v
+}
+
+// UnwrapOr returns the value of the result, or fallback if it's unsuccessful.
+func (r Result[V, E]) UnwrapOr(fallback V) V {
+	if v, ok := r.Ok(); ok {
+		return v
+	}
+
+	return fallback
+}
+
+// UnwrapErr returns the error of the result, panicking if it's successful.
+func (r Result[V, E]) UnwrapErr() E {
+	e, ok := r.Err()
+	if !ok {
+		panic(fmt.Errorf("%s - %w", r, ErrUnwrap))
+	}
+
+	return e
+}
+
+// All returns an iterator over the value of the result, yielding nothing if it's unsuccessful.
+func (r Result[V, E]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if v, ok := r.Ok(); ok {
+			yield(v)
+		}
+	}
+}
+
+// String returns the string representation of a result: Ok(value), Err(error), or Invalid if it's the zero value.
+func (r Result[V, E]) String() string {
+	if v, ok := r.Ok(); ok {
+		return fmt.Sprintf("Ok(%v)", v)
+	}
+
+	if e, ok := r.Err(); ok {
+		return fmt.Sprintf("Err(%v)", e)
+	}
+
+	return "Invalid"
+}
+
+// MapResult returns a result holding the result of fn applied to the value of r, or the error of r if it's unsuccessful.
+func MapResult[V, W, E any](r Result[V, E], fn func(V) W) Result[W, E] {
+	if v, ok := r.Ok(); ok {
+		return Ok[W, E](fn(v))
+	}
+
+	if e, ok := r.Err(); ok {
+		return Err[W](e)
+	}
+
+	return Result[W, E]{}
+}
@@ -0,0 +1,100 @@
+package union_test
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/judah-caruso/unsafex/union"
+)
+
+func TestResult(t *testing.T) {
+	parse := func(s string) union.Result[int, error] {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return union.Err[int](err)
+		}
+
+		return union.Ok[int, error](v)
+	}
+
+	ok := parse("10")
+	bad := parse("ten")
+
+	if !ok.IsOk() || ok.IsErr() {
+		t.Error("expected Ok to be successful")
+	}
+
+	if bad.IsOk() || !bad.IsErr() {
+		t.Error("expected Err to be unsuccessful")
+	}
+
+	if v, isOk := ok.Ok(); !isOk || v != 10 {
+		t.Errorf("expected Ok to return 10, got %v (%v)", v, isOk)
+	}
+
+	if err, isErr := bad.Err(); !isErr || !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("expected Err to return the parse error, got %v (%v)", err, isErr)
+	}
+
+	if _, isErr := ok.Err(); isErr {
+		t.Error("expected Err of a successful result to return false")
+	}
+
+	if v := bad.UnwrapOr(-1); v != -1 {
+		t.Errorf("expected UnwrapOr of Err to return the fallback, got %v", v)
+	}
+
+	doubled := union.MapResult(ok, func(v int) int { return v * 2 })
+	if doubled.Unwrap() != 20 {
+		t.Errorf("expected MapResult to double the value, got %s", doubled)
+	}
+
+	if mapped := union.MapResult(bad, strconv.Itoa); !errors.Is(mapped.UnwrapErr(), strconv.ErrSyntax) {
+		t.Errorf("expected MapResult to keep the error, got %s", mapped)
+	}
+
+	if values := slices.Collect(ok.All()); !slices.Equal(values, []int{10}) {
+		t.Errorf("expected All of Ok to yield its value, got %v", values)
+	}
+
+	if values := slices.Collect(bad.All()); len(values) != 0 {
+		t.Errorf("expected All of Err to yield nothing, got %v", values)
+	}
+
+	// Results with the same value and error type must still be distinguishable.
+	same := union.Err[string]("failed")
+	if same.IsOk() || same.UnwrapErr() != "failed" || same.String() != "Err(failed)" {
+		t.Errorf("result with identical value and error types was incorrect: %s", same)
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, union.ErrUnwrap) {
+			t.Errorf("expected Unwrap of Err to panic: %v", err)
+		}
+	}()
+
+	bad.Unwrap()
+}
+
+func TestResultInvalid(t *testing.T) {
+	var r union.Result[int, error]
+	if r.IsOk() || r.IsErr() || r.String() != "Invalid" {
+		t.Errorf("expected the zero value to be an invalid result, got %s", r)
+	}
+
+	if mapped := union.MapResult(r, strconv.Itoa); mapped.IsOk() || mapped.IsErr() {
+		t.Errorf("expected MapResult to keep invalid results invalid, got %s", mapped)
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, union.ErrUnwrap) {
+			t.Errorf("expected UnwrapErr of an invalid result to panic: %v", err)
+		}
+	}()
+
+	r.UnwrapErr()
+}
@@ -0,0 +1,249 @@
+package union
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrTextMember = errors.New("member cannot be encoded as text")
+
+// MarshalText implements [encoding.TextMarshaler].
+//
+// An initialized union is encoded as the name of the active member (see [Of.MarshalJSON])
+// followed by a colon and its value:
+//
+//	int64:10
+//	Duration:1m30s
+//
+// Values are encoded with their MarshalText method if they have one. Otherwise durations use
+// [time.Duration.String] and booleans, numbers, and strings are formatted with strconv.
+// Other members return [ErrTextMember].
+//
+// An uninitialized union is encoded as empty text.
+func (u Of[T]) MarshalText() ([]byte, error) {
+	if u.tag == 0 {
+		return []byte{}, nil
+	}
+
+	field := getInternalFields(u)[u.tag-1]
+	b := append([]byte(memberName(field)), ':')
+	return appendText(b, u.member(u.tag))
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+//
+// If the text starts with the name of a member followed by a colon, the rest of the text is
+// decoded as that member. Otherwise, each member is tried in declaration order and the first
+// to successfully decode the text becomes the active member:
+//
+//	type Timeout = union.Of[struct {
+//		int64
+//		time.Duration
+//		string
+//	}]
+//
+//	"10"        -> int64(10)
+//	"10s"       -> time.Duration(10 * time.Second)
+//	"forever"   -> "forever"
+//	"string:10" -> "10"
+//
+// Members are decoded with their UnmarshalText method if they have one, otherwise with the
+// parsers matching [Of.MarshalText]. Decoding empty text resets the union to its uninitialized state.
+func (u *Of[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		u.Reset()
+		return nil
+	}
+
+	s := string(text)
+	fields := getInternalFields(*u)
+	if name, value, ok := strings.Cut(s, ":"); ok {
+		if index := memberIndex(fields, name); index >= 0 {
+			if err := u.setText(uint8(index+1), value); err != nil {
+				return fmt.Errorf("decoding %q: %w", name, err)
+			}
+
+			return nil
+		}
+	}
+
+	for i := range min(len(fields), math.MaxUint8) {
+		if u.setText(uint8(i+1), s) == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%q - %w", s, ErrInvalidEncoding)
+}
+
+// setText decodes text as the given member, only making it active if decoding succeeds.
+func (u *Of[T]) setText(tag uint8, text string) error {
+	value := reflect.New(getInternalFields(*u)[tag-1].Type).Elem()
+	if err := parseText(value, text); err != nil {
+		return err
+	}
+
+	u.Reset()
+	u.member(tag).Set(value)
+	u.tag = tag
+	return nil
+}
+
+// Flag returns a [flag.Value] that stores its value in the given union, allowing it to be used as a command-line flag:
+//
+//	var timeout Timeout
+//	flag.Var(union.Flag(&timeout), "timeout", "request timeout in seconds, as a duration, or 'forever'")
+//
+// Flags are decoded with [Of.UnmarshalText] and the union's current value is used as the default.
+func Flag[T anystruct, U MutableUnion[T]](u U) flag.Value {
+	return &flagValue[T]{u: u}
+}
+
+// flagValue adapts a union to the [flag.Getter] interface.
+type flagValue[T anystruct] struct {
+	u MutableUnion[T]
+}
+
+func (f *flagValue[T]) String() string {
+	// The flag package calls String on a zero flagValue to check for default values.
+	if f.u == nil {
+		return ""
+	}
+
+	u := f.u.value()
+	text, err := u.MarshalText()
+	if err != nil {
+		return fmt.Sprint(u)
+	}
+
+	return string(text)
+}
+
+func (f *flagValue[T]) Set(s string) error {
+	u := f.u.value()
+	if err := u.UnmarshalText([]byte(s)); err != nil {
+		return err
+	}
+
+	assign(f.u.locate(), u)
+	return nil
+}
+
+// Get returns the value of the active member, or nil if the union is uninitialized.
+func (f *flagValue[T]) Get() any {
+	u := f.u.value()
+	if u.tag == 0 {
+		return nil
+	}
+
+	return u.member(u.tag).Interface()
+}
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// appendText appends the text encoding of v to b.
+//
+// v is expected to be addressable so methods with pointer receivers can be used.
+func appendText(b []byte, v reflect.Value) ([]byte, error) {
+	if v.Addr().Type().Implements(textMarshalerType) {
+		text, err := v.Addr().Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+
+		return append(b, text...), nil
+	}
+
+	if v.Type() == durationType {
+		return append(b, time.Duration(v.Int()).String()...), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.AppendBool(b, v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(b, v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(b, v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(b, v.Float(), 'g', -1, v.Type().Bits()), nil
+	case reflect.Complex64, reflect.Complex128:
+		return append(b, strconv.FormatComplex(v.Complex(), 'g', -1, v.Type().Bits())...), nil
+	case reflect.String:
+		return append(b, v.String()...), nil
+	}
+
+	return nil, fmt.Errorf("%s - %w", v.Type(), ErrTextMember)
+}
+
+// parseText decodes text into v, which must be addressable.
+func parseText(v reflect.Value, text string) error {
+	if v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(text, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	case reflect.Complex64, reflect.Complex128:
+		c, err := strconv.ParseComplex(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetComplex(c)
+	case reflect.String:
+		v.SetString(text)
+	default:
+		return fmt.Errorf("%s - %w", v.Type(), ErrTextMember)
+	}
+
+	return nil
+}
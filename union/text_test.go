@@ -0,0 +1,147 @@
+package union_test
+
+import (
+	"errors"
+	"flag"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/judah-caruso/unsafex/union"
+)
+
+type timeout = union.Of[struct {
+	int64
+	time.Duration
+	string `union:"str"`
+}]
+
+func TestTextRoundTrip(t *testing.T) {
+	type Value = union.Of[struct {
+		bool
+		uint16
+		float64
+		netip.Addr
+		time.Duration
+		string
+	}]
+
+	var v Value
+	data, err := v.MarshalText()
+	if err != nil || len(data) != 0 {
+		t.Errorf("expected uninitialized union to marshal as empty text, was %q (%v)", data, err)
+	}
+
+	cases := []struct {
+		set    func(v *Value)
+		expect string
+	}{
+		{func(v *Value) { union.Set(v, true) }, "bool:true"},
+		{func(v *Value) { union.Set(v, uint16(8080)) }, "uint16:8080"},
+		{func(v *Value) { union.Set(v, 0.25) }, "float64:0.25"},
+		{func(v *Value) { union.Set(v, netip.MustParseAddr("127.0.0.1")) }, "Addr:127.0.0.1"},
+		{func(v *Value) { union.Set(v, 90*time.Second) }, "Duration:1m30s"},
+		{func(v *Value) { union.Set(v, "true") }, "string:true"},
+	}
+
+	for _, c := range cases {
+		c.set(&v)
+		data, err := v.MarshalText()
+		if err != nil || string(data) != c.expect {
+			t.Errorf("expected %q, got %q (%v)", c.expect, data, err)
+			continue
+		}
+
+		var decoded Value
+		if err := decoded.UnmarshalText(data); err != nil {
+			t.Errorf("failed to unmarshal %q: %s", data, err)
+			continue
+		}
+
+		if !union.Equal(v, decoded) {
+			t.Errorf("expected %q to round trip, got %v", data, decoded)
+		}
+	}
+}
+
+func TestTextDeclarationOrder(t *testing.T) {
+	var v timeout
+	cases := []struct {
+		text  string
+		check func() bool
+	}{
+		{"10", func() bool { return union.Is[int64](v) && union.Get[int64](v) == 10 }},
+		{"0x10", func() bool { return union.Is[int64](v) && union.Get[int64](v) == 16 }},
+		{"10s", func() bool { return union.Is[time.Duration](v) && union.Get[time.Duration](v) == 10*time.Second }},
+		{"forever", func() bool { return union.Is[string](v) && union.Get[string](v) == "forever" }},
+		{"str:10", func() bool { return union.Is[string](v) && union.Get[string](v) == "10" }},
+		{"http://localhost", func() bool { return union.Get[string](v) == "http://localhost" }},
+	}
+
+	for _, c := range cases {
+		if err := v.UnmarshalText([]byte(c.text)); err != nil {
+			t.Errorf("failed to unmarshal %q: %s", c.text, err)
+			continue
+		}
+
+		if !c.check() {
+			t.Errorf("%q decoded to the wrong member: %v", c.text, v)
+		}
+	}
+
+	if err := v.UnmarshalText(nil); err != nil || !v.IsEmpty() {
+		t.Errorf("expected empty text to reset the union: %v (%v)", v, err)
+	}
+}
+
+func TestTextInvalid(t *testing.T) {
+	type Value = union.Of[struct {
+		int8
+		bool
+	}]
+
+	var v Value
+	if err := v.UnmarshalText([]byte("1000")); !errors.Is(err, union.ErrInvalidEncoding) {
+		t.Errorf("expected text no member can decode to fail: %v", err)
+	}
+
+	if err := v.UnmarshalText([]byte("bool:maybe")); err == nil {
+		t.Errorf("expected invalid text for the named member to fail")
+	}
+
+	if !v.IsEmpty() {
+		t.Errorf("expected failed decoding to leave the union unchanged: %v", v)
+	}
+
+	type buffer []byte
+	var s union.Of[struct{ buffer }]
+	union.Set(&s, buffer("hello"))
+	if _, err := s.MarshalText(); !errors.Is(err, union.ErrTextMember) {
+		t.Errorf("expected slice member to fail encoding: %v", err)
+	}
+}
+
+func TestFlag(t *testing.T) {
+	var v timeout
+	union.Set(&v, 5*time.Second)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(union.Flag(&v), "timeout", "request timeout")
+
+	if def := fs.Lookup("timeout").DefValue; def != "Duration:5s" {
+		t.Errorf("expected the current value to be the default, was %q", def)
+	}
+
+	if err := fs.Parse([]string{"-timeout", "30"}); err != nil {
+		t.Fatalf("failed to parse flags: %s", err)
+	}
+
+	if !union.Is[int64](v) || union.Get[int64](v) != 30 {
+		t.Errorf("expected flag to set the union: %v", v)
+	}
+
+	getter := fs.Lookup("timeout").Value.(flag.Getter)
+	if got, ok := getter.Get().(int64); !ok || got != 30 {
+		t.Errorf("expected Get to return the active member, was %v", getter.Get())
+	}
+}
@@ -5,14 +5,15 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"unsafe"
 
 	"github.com/judah-caruso/unsafex"
-	"github.com/judah-caruso/unsafex/rawptr"
 )
 
 var (
 	ErrUninitializedAccess = errors.New("access of uninitialized union")
 	ErrInvalidType         = errors.New("type does not exist within union")
+	ErrInactiveMember      = errors.New("type is not the active member of union")
 )
 
 // anystruct represents a struct type with any members.
@@ -21,10 +22,6 @@ var (
 // this, anystruct is here for documentation purposes.
 type anystruct any
 
-// @note(judah): is there a way to declare the type parameters
-// to allow 'type Value union.Of[...]' so users can define their
-// own methods?
-
 // Of represents a union of different types.
 //
 // Since members are accessed by type instead of name,
@@ -35,27 +32,132 @@ type anystruct any
 //		uint32
 //		float32
 //	})
+//
+// Unlike a C union, members do not overlap: each member is stored in its own slot
+// of an inline T, so a union is the size of T (every member added together) plus its tag.
+// Use [Inline] for a union sized and aligned to its largest member, which overlaps
+// members in an explicit storage type at the cost of not allowing pointers.
+//
+// In exchange, the garbage collector always sees the pointers held by the active member.
+// Setting a member clears the previously active one, and getters read the active
+// member's memory, allowing it to be reinterpreted as a type of the same size or smaller.
+//
+// Because storage is inline, the zero value is an empty union ready for use,
+// and copying a union copies its value rather than aliasing it (though memory
+// referenced by the active member is still shared, see [DeepClone]). This also means
+// a union cannot contain itself by value; recursive unions must use pointers:
+//
+//	type (
+//		Expr = union.Of[struct {
+//			Binary
+//			Int
+//		}]
+//		Binary struct{ Lhs, Rhs *Expr }
+//		Int    int64
+//	)
 type Of[T anystruct] struct {
-	typ reflect.Kind
-	mem []byte
+	tag uint8 // index of the active member + 1; 0 if the union is uninitialized.
+	mem T
+}
+
+// Union is implemented by [Of], [Inline], and any type that embeds them.
+//
+// Functions accepting a Union allow named union types to be used directly,
+// so they can define their own methods:
+//
+//	type Expr struct {
+//		union.Of[struct {
+//			Binary
+//			Int
+//		}]
+//	}
+//
+//	func (e Expr) Eval() float64 {
+//		if union.Is[Int](e) {
+//			return float64(union.Get[Int](e))
+//		}
+//		...
+//	}
+type Union[T anystruct] interface {
+	value() Of[T]
+}
+
+// MutableUnion is implemented by pointers to [Of], [Inline], and any type that embeds them.
+//
+// See [Union] for more information.
+type MutableUnion[T anystruct] interface {
+	Union[T]
+	locate() location
+}
+
+// location is where a union keeps its tag and members, allowing [Of] and [Inline] to share their implementation.
+type location struct {
+	tag     *uint8
+	mem     unsafe.Pointer
+	overlap bool // Whether every member is stored at mem, rather than at its offset within T.
+}
+
+// slot returns the address of a member within the union's backing memory.
+func (loc location) slot(field reflect.StructField) unsafe.Pointer {
+	if loc.overlap {
+		return loc.mem
+	}
+
+	return unsafe.Add(loc.mem, field.Offset)
+}
+
+// member returns the addressable value stored in the slot of the given member.
+func (loc location) member(field reflect.StructField) reflect.Value {
+	return reflect.NewAt(field.Type, loc.slot(field)).Elem()
+}
+
+// reset clears the active member and returns the union to its uninitialized state.
+func (loc location) reset(l *layout) {
+	if tag := *loc.tag; tag != 0 {
+		l.clear(loc.slot(l.fields[tag-1]), tag)
+		*loc.tag = 0
+	}
+}
+
+// assign replaces the union at loc with u.
+func assign[T anystruct](loc location, u Of[T]) {
+	l := layoutOf[T]()
+	loc.reset(l)
+	if u.tag == 0 {
+		return
+	}
+
+	field := l.fields[u.tag-1]
+	loc.member(field).Set(u.member(u.tag))
+	*loc.tag = u.tag
+}
+
+// value returns the union, allowing [Union] to be satisfied by embedding.
+func (u Of[T]) value() Of[T] {
+	return u
+}
+
+// locate returns where the union keeps its members, allowing [MutableUnion] to be satisfied by embedding.
+func (u *Of[T]) locate() location {
+	return location{tag: &u.tag, mem: unsafe.Pointer(&u.mem)}
 }
 
 // String returns the string representation of a union.
 func (u Of[T]) String() string {
 	t := getInternalType(u)
+	fields := getInternalFields(u)
 
 	var b strings.Builder
 	b.WriteString("union[")
-	if u.typ == reflect.Invalid {
+	if u.tag == 0 {
 		b.WriteString("none")
 	} else {
-		b.WriteString(u.typ.String())
+		b.WriteString(fields[u.tag-1].Type.String())
 	}
 	b.WriteString("] {")
 
 	if t.Kind() == reflect.Struct {
 		b.WriteByte(' ')
-		fields := reflect.VisibleFields(t)
 		for i, field := range fields {
 			b.WriteString(field.Type.String())
 			if i < len(fields)-1 {
@@ -69,82 +171,214 @@ func (u Of[T]) String() string {
 	return b.String()
 }
 
+// Reset returns a union to its uninitialized state.
+//
+// The memory of the active member is zeroed so any pointers it held are released.
+func (u *Of[T]) Reset() {
+	if u.tag == 0 {
+		return
+	}
+
+	u.locate().reset(layoutOf[T]())
+}
+
+// IsEmpty returns true if no member is stored in the union.
+func (u Of[T]) IsEmpty() bool {
+	return u.tag == 0
+}
+
+// Which returns the active member of a union, or false if the union is uninitialized.
+func (u Of[T]) Which() (Member, bool) {
+	if u.tag == 0 {
+		return Member{Index: -1}, false
+	}
+
+	index := int(u.tag - 1)
+	return newMember(index, getInternalFields(u)[index]), true
+}
+
+// Member describes a single member of a union.
+type Member struct {
+	Index int               // Index of the member within the union's internal type.
+	Name  string            // Name of the member, see [Of.MarshalJSON].
+	Type  reflect.Type      // Type of the member.
+	Tag   reflect.StructTag // Tag of the member's field.
+}
+
+// Members returns descriptors for every member of the union type U in declaration order.
+func Members[U Union[T], T anystruct]() []Member {
+	fields := getInternalFields(Of[T]{})
+	members := make([]Member, len(fields))
+	for i, field := range fields {
+		members[i] = newMember(i, field)
+	}
+
+	return members
+}
+
+// newMember returns the descriptor for a member.
+func newMember(index int, field reflect.StructField) Member {
+	return Member{
+		Index: index,
+		Name:  memberName(field),
+		Type:  field.Type,
+		Tag:   field.Tag,
+	}
+}
+
 // Is returns true if the given type is currently stored in the union.
-func Is[E any, T anystruct](u Of[T]) bool {
-	// Explicit invalid check to make sure invalid types don't result in false-positives.
-	if u.typ == reflect.Invalid {
+func Is[E any, T anystruct, U Union[T]](u U) bool {
+	// Explicit uninitialized check to make sure invalid types don't result in false-positives.
+	tag := u.value().tag
+	if tag == 0 {
 		return false
 	}
 
-	return u.typ == reflect.TypeFor[E]().Kind()
+	return tag == memberTag[E, T]()
 }
 
 // Set overwrites the backing memory of a union with the given value; initializing the union if uninitialized.
 //
 // Set is unsafe and will not verify if the backing memory has enough capacity to store the value.
+// However, it will panic if V is not a member of the union as the active member could not be tracked.
 // Use [SetSafe] for more safety checks.
-func Set[V any, T anystruct](u *Of[T], value V) {
-	if u.mem == nil {
-		u.mem = make([]byte, unsafex.SizeOf[T]())
+func Set[V any, T anystruct, U MutableUnion[T]](u U, value V) {
+	l := layoutOf[T]()
+	tag := l.tag(reflect.TypeFor[V]())
+	if tag == 0 {
+		panic(fmt.Errorf("%s - %w", reflect.TypeFor[V](), ErrInvalidType))
 	}
 
-	*rawptr.To[V](rawptr.From(&u.mem[0])) = value
-	u.typ = reflect.TypeFor[V]().Kind()
+	setMember(u.locate(), l, tag, value)
 }
 
 // SetSafe overwrites the backing memory of a union with the given value,
 // returning an error if the value cannot be stored in the union.
 //
 // Use [Set] for fewer safety checks.
-func SetSafe[V any, T anystruct](u *Of[T], value V) error {
-	if u.mem == nil {
-		u.mem = make([]byte, unsafex.SizeOf[T]())
-	}
-
-	vt := reflect.TypeFor[V]()
-	for _, field := range getInternalFields(*u) {
-		if field.Type == vt {
-			*rawptr.To[V](rawptr.From(&u.mem[0])) = value
-			u.typ = reflect.TypeFor[V]().Kind()
-			return nil
-		}
+func SetSafe[V any, T anystruct, U MutableUnion[T]](u U, value V) error {
+	l := layoutOf[T]()
+	tag := l.tag(reflect.TypeFor[V]())
+	if tag == 0 {
+		return fmt.Errorf("%s - %w", reflect.TypeFor[V](), ErrInvalidType)
 	}
 
-	return fmt.Errorf("%s - %w", vt, ErrInvalidType)
+	setMember(u.locate(), l, tag, value)
+	return nil
 }
 
 // Get returns the union's backing memory interpreted as a value of type V, panicking if the union is uninitialized.
 //
-// Get is unsafe and will not verify if the type exists within the union.
-// Use [GetSafe] for more safety checks.
-func Get[V any, T anystruct](u Of[T]) V {
-	if u.mem == nil {
-		panic(ErrUninitializedAccess)
-	}
-
-	return rawptr.Cast[V](rawptr.From(&u.mem[0])).Deref()
+// Get is unsafe and will not verify if the type exists within the union, only that it fits within the
+// memory of the active member when assertions are enabled. Use [GetSafe] for more safety checks.
+func Get[V any, T anystruct, U Union[T]](u U) V {
+	value := u.value()
+	return *activePtr[V, T](value.locate())
 }
 
 // GetSafe returns the union's backing memory interpreted as a value of type V, returning an error if the type
-// does not exist within the union or the union is uninitialized.
+// does not exist within the union, is not the active member, or the union is uninitialized.
 //
 // Use [Get] for fewer safety checks.
-func GetSafe[V any, T anystruct](u Of[T]) (V, error) {
-	if u.mem == nil {
+func GetSafe[V any, T anystruct, U Union[T]](u U) (V, error) {
+	value := u.value()
+	ptr, err := activePtrSafe[V, T](value.locate())
+	if err != nil {
 		var zero V
-		return zero, ErrUninitializedAccess
+		return zero, err
 	}
 
-	ut := getInternalType(u)
-	vt := reflect.TypeFor[V]()
-	for _, field := range reflect.VisibleFields(ut) {
-		if field.Type == vt {
-			return rawptr.Cast[V](rawptr.From(&u.mem[0])).Deref(), nil
-		}
+	return *ptr, nil
+}
+
+// Ptr returns a pointer to the union's backing memory interpreted as type V, panicking if the union is uninitialized.
+// This allows large members to be modified in place without copying.
+//
+// The pointer is only valid while the active member is unchanged. Once another member is set,
+// or the union is reset, the memory it points to is cleared or reused.
+//
+// Ptr is unsafe and will not verify if the type exists within the union, only that it fits within the
+// memory of the active member when assertions are enabled. Use [PtrSafe] for more safety checks.
+func Ptr[V any, T anystruct, U MutableUnion[T]](u U) *V {
+	return activePtr[V, T](u.locate())
+}
+
+// PtrSafe returns a pointer to the union's backing memory interpreted as type V, returning an error if the type
+// does not exist within the union, is not the active member, or the union is uninitialized.
+//
+// Use [Ptr] for fewer safety checks.
+func PtrSafe[V any, T anystruct, U MutableUnion[T]](u U) (*V, error) {
+	return activePtrSafe[V, T](u.locate())
+}
+
+// activePtr returns a pointer to the active member's memory interpreted as type V, panicking if the union is uninitialized.
+func activePtr[V any, T anystruct](loc location) *V {
+	tag := *loc.tag
+	if tag == 0 {
+		panic(ErrUninitializedAccess)
 	}
 
-	var zero V
-	return zero, ErrInvalidType
+	field := layoutOf[T]().fields[tag-1]
+	// Checking against the member's slot rather than T ensures V can't reach into the slots of other members.
+	unsafex.Assert(unsafex.SizeOf[V]() <= field.Type.Size(), "%s does not fit within the memory of %s", reflect.TypeFor[V](), field.Type)
+
+	return (*V)(loc.slot(field))
+}
+
+// activePtrSafe returns a pointer to the active member's memory, returning an error if it's not of type V.
+func activePtrSafe[V any, T anystruct](loc location) (*V, error) {
+	if *loc.tag == 0 {
+		return nil, ErrUninitializedAccess
+	}
+
+	l := layoutOf[T]()
+	tag := l.tag(reflect.TypeFor[V]())
+	if tag == 0 {
+		return nil, fmt.Errorf("%s - %w", reflect.TypeFor[V](), ErrInvalidType)
+	}
+
+	if tag != *loc.tag {
+		return nil, fmt.Errorf("%s - %w", reflect.TypeFor[V](), ErrInactiveMember)
+	}
+
+	return (*V)(loc.slot(l.fields[tag-1])), nil
+}
+
+// setMember stores value in the slot of the given member, clearing the previously active member.
+func setMember[V any](loc location, l *layout, tag uint8, value V) {
+	if *loc.tag != tag {
+		loc.reset(l)
+	}
+
+	*(*V)(loc.slot(l.fields[tag-1])) = value
+	*loc.tag = tag
+}
+
+// member returns the addressable value stored in the slot of the given member.
+func (u *Of[T]) member(tag uint8) reflect.Value {
+	field := getInternalFields(*u)[tag-1]
+	return reflect.NewAt(field.Type, u.slot(field)).Elem()
+}
+
+// memberName returns the name identifying a member when encoded.
+//
+// It defaults to the name of the field, but can be overridden with a 'union' struct tag.
+func memberName(field reflect.StructField) string {
+	if name := field.Tag.Get("union"); name != "" {
+		return name
+	}
+
+	return field.Name
+}
+
+// slot returns the address of a member within the union's backing memory.
+func (u *Of[T]) slot(field reflect.StructField) unsafe.Pointer {
+	return unsafe.Add(unsafe.Pointer(&u.mem), field.Offset)
+}
+
+// bytes returns the memory of a member's slot.
+func (u *Of[T]) bytes(field reflect.StructField) []byte {
+	return unsafe.Slice((*byte)(u.slot(field)), field.Type.Size())
 }
 
 // getInternalType returns the internal type for a union.
@@ -156,21 +390,40 @@ func getInternalType[U Of[T], T anystruct](_ U) reflect.Type {
 	return reflect.TypeFor[T]()
 }
 
+// memberTag returns the tag identifying V within the union's internal type.
+//
+// Members are identified by their exact type, so named types are distinct
+// from their underlying types. It returns 0 if V is not a member.
+//
+// Note: only the first 255 members of a union can be tagged.
+func memberTag[V any, T anystruct]() uint8 {
+	return layoutOf[T]().tag(reflect.TypeFor[V]())
+}
+
 // getInternalFields returns an array of reflect.StructField belonging
 // to the internal type of a union.
 //
+// The fields are computed once per type and shared, so they must not be modified.
 // It returns an empty array if the internal type is not a struct.
 func getInternalFields[U Of[T], T anystruct](_ U) []reflect.StructField {
-	var fields []reflect.StructField
-
-	backing := reflect.TypeFor[T]()
-	if backing.Kind() != reflect.Struct {
-		return fields
-	}
+	return layoutOf[T]().fields
+}
 
-	for i := range backing.NumField() {
-		fields = append(fields, backing.Field(i))
+// hasPointers returns true if values of the given type contain pointers.
+func hasPointers(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.UnsafePointer, reflect.String, reflect.Slice,
+		reflect.Map, reflect.Chan, reflect.Func, reflect.Interface:
+		return true
+	case reflect.Array:
+		return t.Len() > 0 && hasPointers(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if hasPointers(t.Field(i).Type) {
+				return true
+			}
+		}
 	}
 
-	return fields
+	return false
 }
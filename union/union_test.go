@@ -1,7 +1,14 @@
 package union_test
 
 import (
+	"bytes"
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/judah-caruso/unsafex/union"
 )
@@ -36,8 +43,8 @@ type (
 	}]
 	binaryExpr struct {
 		Op  string
-		Lhs expr
-		Rhs expr
+		Lhs *expr
+		Rhs *expr
 	}
 	intExpr   int64
 	floatExpr float64
@@ -55,8 +62,8 @@ func TestUnionOfStructs(t *testing.T) {
 	makeBinop := func(op string, lhs, rhs expr) (e expr) {
 		union.Set(&e, binaryExpr{
 			Op:  op,
-			Lhs: lhs,
-			Rhs: rhs,
+			Lhs: &lhs,
+			Rhs: &rhs,
 		})
 		return
 	}
@@ -66,10 +73,10 @@ func TestUnionOfStructs(t *testing.T) {
 	if bin1.Op != "+" {
 		t.Errorf("incorrect op returned from union: %s", bin1.Op)
 	}
-	if lhs := union.Get[intExpr](bin1.Lhs); lhs != 10 {
+	if lhs := union.Get[intExpr](*bin1.Lhs); lhs != 10 {
 		t.Errorf("incorrect lhs returned from union: %v", lhs)
 	}
-	if rhs := union.Get[intExpr](bin1.Rhs); rhs != 20 {
+	if rhs := union.Get[intExpr](*bin1.Rhs); rhs != 20 {
 		t.Errorf("incorrect rhs returned from union: %v", rhs)
 	}
 
@@ -78,10 +85,10 @@ func TestUnionOfStructs(t *testing.T) {
 	if bin2.Op != "-" {
 		t.Errorf("incorrect op returned from union of union: %s", bin2.Op)
 	}
-	if lhs := union.Get[binaryExpr](bin2.Lhs); lhs.Op != "+" {
+	if lhs := union.Get[binaryExpr](*bin2.Lhs); lhs.Op != "+" {
 		t.Errorf("incorrect lhs returned from union of union: %v", lhs)
 	}
-	if rhs := union.Get[floatExpr](bin2.Rhs); rhs != 3.14 {
+	if rhs := union.Get[floatExpr](*bin2.Rhs); rhs != 3.14 {
 		t.Errorf("incorrect rhs returned from union of union: %v", rhs)
 	}
 }
@@ -190,3 +197,478 @@ func TestUnionSafe(t *testing.T) {
 		t.Errorf("GetSafe returned invalid value: %v", v)
 	}
 }
+
+func TestUnionExactTags(t *testing.T) {
+	type (
+		otherExpr struct{ Value int64 }
+		Value     = union.Of[struct {
+			binaryExpr
+			otherExpr
+			intExpr
+			int64
+			*uint64
+			*float64
+		}]
+	)
+
+	var v Value
+	union.Set(&v, intExpr(10))
+
+	if !union.Is[intExpr](v) {
+		t.Error("expected intExpr to be the active member")
+	}
+
+	if union.Is[int64](v) {
+		t.Error("int64 was reported as active when intExpr was set")
+	}
+
+	if _, err := union.GetSafe[int64](v); !errors.Is(err, union.ErrInactiveMember) {
+		t.Errorf("expected GetSafe of an inactive member to fail, got %v", err)
+	}
+
+	union.Set(&v, otherExpr{Value: 20})
+	if union.Is[binaryExpr](v) || !union.Is[otherExpr](v) {
+		t.Error("struct members were not distinguished")
+	}
+
+	if got := v.String(); !strings.HasPrefix(got, "union[union_test.otherExpr]") {
+		t.Errorf("unexpected stringification of struct member: %s", got)
+	}
+
+	value := uint64(30)
+	union.Set(&v, &value)
+	if union.Is[*float64](v) || !union.Is[*uint64](v) {
+		t.Error("pointer members were not distinguished")
+	}
+
+	if _, err := union.GetSafe[string](v); !errors.Is(err, union.ErrInvalidType) {
+		t.Errorf("expected GetSafe of a promoted field type to fail, got %v", err)
+	}
+}
+
+func TestUnionValueSemantics(t *testing.T) {
+	type Value = union.Of[struct {
+		int64
+		string
+	}]
+
+	var a Value
+	if a.String() != "union[none] { int64; string }" {
+		t.Errorf("zero value union was not empty: %s", a.String())
+	}
+
+	union.Set(&a, int64(10))
+
+	b := a
+	union.Set(&b, "hello")
+
+	if !union.Is[int64](a) || union.Get[int64](a) != 10 {
+		t.Errorf("modifying a copy changed the original: %s", a.String())
+	}
+
+	if !union.Is[string](b) || union.Get[string](b) != "hello" {
+		t.Errorf("copy did not hold its own value: %s", b.String())
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		union.Set(&a, int64(20))
+		union.Set(&a, "world")
+	})
+	if allocs != 0 {
+		t.Errorf("expected Set to not allocate, allocated %v times", allocs)
+	}
+}
+
+func TestUnionKeepsPointersAlive(t *testing.T) {
+	type (
+		payload [64]uint64
+		buffer  []byte
+		Value   = union.Of[struct {
+			*payload
+			string
+			buffer
+			any
+			binaryExpr
+		}]
+	)
+
+	var freed atomic.Bool
+
+	collect := func() {
+		for range 4 {
+			runtime.GC()
+			time.Sleep(time.Millisecond) // Give finalizers a chance to run.
+		}
+	}
+
+	var v Value
+	func() {
+		p := &payload{0: 0xDEAD_BEEF}
+		runtime.SetFinalizer(p, func(*payload) { freed.Store(true) })
+		union.Set(&v, p)
+	}()
+
+	collect()
+	if freed.Load() {
+		t.Fatal("pointer member was collected while stored in a union")
+	}
+
+	if p := union.Get[*payload](v); p[0] != 0xDEAD_BEEF {
+		t.Errorf("pointer member was corrupted: %X", p[0])
+	}
+
+	union.Set(&v, strings.Repeat("union", 64))
+	collect()
+	if s := union.Get[string](v); s != strings.Repeat("union", 64) {
+		t.Errorf("string member was corrupted: %q", s)
+	}
+
+	union.Set(&v, buffer(bytes.Repeat([]byte{0xAB}, 1024)))
+	collect()
+	if b := union.Get[buffer](v); !bytes.Equal(b, bytes.Repeat([]byte{0xAB}, 1024)) {
+		t.Error("slice member was corrupted")
+	}
+
+	union.Set[any](&v, &payload{0: 10})
+	collect()
+	if i := union.Get[any](v); i.(*payload)[0] != 10 {
+		t.Errorf("interface member was corrupted: %v", i)
+	}
+
+	var lhs, rhs expr
+	union.Set(&lhs, intExpr(1))
+	union.Set(&rhs, floatExpr(2))
+	union.Set(&v, binaryExpr{Op: strings.Clone("*"), Lhs: &lhs, Rhs: &rhs})
+	collect()
+
+	bin := union.Get[binaryExpr](v)
+	if bin.Op != "*" || union.Get[intExpr](*bin.Lhs) != 1 || union.Get[floatExpr](*bin.Rhs) != 2 {
+		t.Errorf("struct member was corrupted: %+v", bin)
+	}
+}
+
+func TestUnionReset(t *testing.T) {
+	type (
+		payload [64]uint64
+		Value   = union.Of[struct {
+			*payload
+			int64
+		}]
+	)
+
+	var (
+		v     Value
+		freed atomic.Bool
+	)
+
+	if !v.IsEmpty() {
+		t.Error("expected zero value union to be empty")
+	}
+
+	func() {
+		p := new(payload)
+		runtime.SetFinalizer(p, func(*payload) { freed.Store(true) })
+		union.Set(&v, p)
+	}()
+
+	if v.IsEmpty() {
+		t.Error("expected union to not be empty after Set")
+	}
+
+	v.Reset()
+
+	if !v.IsEmpty() || union.Is[*payload](v) {
+		t.Errorf("expected union to be empty after Reset: %s", v.String())
+	}
+
+	if v.String() != "union[none] { *union_test.payload; int64 }" {
+		t.Errorf("unexpected stringification after Reset: %s", v.String())
+	}
+
+	if _, err := union.GetSafe[*payload](v); !errors.Is(err, union.ErrUninitializedAccess) {
+		t.Errorf("expected GetSafe to fail after Reset: %v", err)
+	}
+
+	for range 4 {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+
+	if !freed.Load() {
+		t.Error("pointer member was not released by Reset")
+	}
+}
+
+func TestUnionWhich(t *testing.T) {
+	type Value = union.Of[struct {
+		int32
+		*uint64 `union:"ptr" note:"pointer"`
+	}]
+
+	members := union.Members[Value]()
+	if len(members) != 2 {
+		t.Fatalf("expected 2 members, got %d", len(members))
+	}
+
+	expected := []union.Member{
+		{Index: 0, Name: "int32", Type: reflect.TypeFor[int32]()},
+		{Index: 1, Name: "ptr", Type: reflect.TypeFor[*uint64](), Tag: `union:"ptr" note:"pointer"`},
+	}
+	for i, m := range members {
+		if m != expected[i] {
+			t.Errorf("unexpected member %d: %+v", i, m)
+		}
+	}
+
+	var v Value
+	if m, ok := v.Which(); ok || m.Index != -1 {
+		t.Errorf("expected Which to fail for an uninitialized union: %+v", m)
+	}
+
+	union.Set(&v, new(uint64))
+	m, ok := v.Which()
+	if !ok || m != members[1] {
+		t.Errorf("expected Which to return the pointer member: %+v", m)
+	}
+
+	if m.Tag.Get("note") != "pointer" {
+		t.Errorf("expected member tag to be preserved: %q", m.Tag)
+	}
+}
+
+func TestUnionPtr(t *testing.T) {
+	var lhs, rhs, e expr
+	union.Set(&lhs, intExpr(1))
+	union.Set(&rhs, intExpr(2))
+	union.Set(&e, binaryExpr{Op: "+", Lhs: &lhs, Rhs: &rhs})
+
+	bin := union.Ptr[binaryExpr](&e)
+	bin.Op = "-"
+	bin.Lhs, bin.Rhs = bin.Rhs, bin.Lhs
+
+	updated := union.Get[binaryExpr](e)
+	if updated.Op != "-" || updated.Lhs != &rhs || updated.Rhs != &lhs {
+		t.Errorf("modification through Ptr did not update the union: %+v", updated)
+	}
+
+	if _, err := union.PtrSafe[intExpr](&e); !errors.Is(err, union.ErrInactiveMember) {
+		t.Errorf("expected PtrSafe of an inactive member to fail: %v", err)
+	}
+
+	if _, err := union.PtrSafe[string](&e); !errors.Is(err, union.ErrInvalidType) {
+		t.Errorf("expected PtrSafe of a non-member to fail: %v", err)
+	}
+
+	i, err := union.PtrSafe[intExpr](&lhs)
+	if err != nil {
+		t.Fatalf("expected PtrSafe of the active member to succeed: %s", err)
+	}
+
+	*i += 10
+	if v := union.Get[intExpr](lhs); v != 11 {
+		t.Errorf("modification through PtrSafe did not update the union: %v", v)
+	}
+
+	lhs.Reset()
+	if _, err := union.PtrSafe[intExpr](&lhs); !errors.Is(err, union.ErrUninitializedAccess) {
+		t.Errorf("expected PtrSafe to fail after Reset: %v", err)
+	}
+}
+
+type (
+	calc struct {
+		union.Of[struct {
+			calcAdd
+			calcNum
+		}]
+	}
+	calcAdd struct{ Lhs, Rhs *calc }
+	calcNum float64
+)
+
+func (c calc) Eval() float64 {
+	v, err := union.Match(c,
+		union.On(func(a calcAdd) float64 { return a.Lhs.Eval() + a.Rhs.Eval() }),
+		union.On(func(n calcNum) float64 { return float64(n) }),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+func TestNamedUnion(t *testing.T) {
+	var lhs, rhs, sum calc
+	union.Set(&lhs, calcNum(1.5))
+	union.Set(&rhs, calcNum(2))
+	if err := union.SetSafe(&sum, calcAdd{Lhs: &lhs, Rhs: &rhs}); err != nil {
+		t.Fatalf("SetSafe failed for named union: %s", err)
+	}
+
+	if v := sum.Eval(); v != 3.5 {
+		t.Errorf("expected named union to evaluate to 3.5, was %v", v)
+	}
+
+	if !union.Is[calcAdd](sum) || union.Is[calcNum](sum) {
+		t.Errorf("Is reported the wrong member for named union: %s", sum.String())
+	}
+
+	if v, err := union.GetSafe[calcNum](lhs); err != nil || v != 1.5 {
+		t.Errorf("GetSafe failed for named union: %v (%v)", v, err)
+	}
+
+	*union.Ptr[calcNum](&rhs) = 10
+	if v := union.Get[calcNum](rhs); v != 10 {
+		t.Errorf("Ptr did not modify named union: %v", v)
+	}
+
+	if sum.String() != "union[union_test.calcAdd] { union_test.calcAdd; union_test.calcNum }" {
+		t.Errorf("unexpected stringification of named union: %s", sum.String())
+	}
+
+	if err := union.Validate[calc](); err != nil {
+		t.Errorf("expected named union to pass validation: %s", err)
+	}
+
+	if members := union.Members[calc](); len(members) != 2 || members[1].Name != "calcNum" {
+		t.Errorf("unexpected members of named union: %v", members)
+	}
+
+	negate := union.MustSwitch[calc](
+		union.On(func(a calcAdd) float64 { return -a.Lhs.Eval() - a.Rhs.Eval() }),
+		union.On(func(n calcNum) float64 { return -float64(n) }),
+	)
+	if v := negate.Match(sum); v != -11.5 {
+		t.Errorf("expected switch over named union to return -11.5, was %v", v)
+	}
+
+	if c := union.Clone(sum); union.Get[calcAdd](c).Lhs != &lhs {
+		t.Errorf("expected Clone of named union to share memory, was %s", c.String())
+	}
+
+	if c := union.DeepClone(sum); union.Get[calcAdd](c).Lhs == &lhs || c.Eval() != sum.Eval() {
+		t.Errorf("expected DeepClone of named union to copy memory, was %s", c.String())
+	}
+
+	if !union.Equal(lhs, lhs) || union.Equal(lhs, rhs) {
+		t.Error("Equal returned incorrect results for named unions")
+	}
+}
+
+type (
+	benchUnion = union.Of[struct {
+		benchInt
+		benchFloat
+		benchString
+	}]
+	benchInt    int64
+	benchFloat  float64
+	benchString string
+
+	// benchSum is the interface-based equivalent of benchUnion.
+	benchSum interface{ isBenchSum() }
+)
+
+func (benchInt) isBenchSum()    {}
+func (benchFloat) isBenchSum()  {}
+func (benchString) isBenchSum() {}
+
+var (
+	benchSink     int64
+	benchSumSink  benchSum
+	benchBoolSink bool
+
+	// benchSharedSink is written by parallel benchmarks.
+	benchSharedSink atomic.Bool
+)
+
+func BenchmarkUnionSetGet(b *testing.B) {
+	var u benchUnion
+	for i := range b.N {
+		union.Set(&u, benchInt(i))
+		benchSink += int64(union.Get[benchInt](u))
+	}
+}
+
+func BenchmarkUnionSetSwitchMember(b *testing.B) {
+	var u benchUnion
+	for i := range b.N {
+		union.Set(&u, benchInt(i))
+		union.Set(&u, benchFloat(i))
+	}
+}
+
+func BenchmarkUnionIs(b *testing.B) {
+	var u benchUnion
+	union.Set(&u, benchString("hello"))
+	for range b.N {
+		benchBoolSink = union.Is[benchString](u)
+	}
+}
+
+func BenchmarkUnionIsAlternating(b *testing.B) {
+	type other = union.Of[struct {
+		benchInt
+		benchString
+	}]
+
+	var u benchUnion
+	var v other
+	union.Set(&u, benchInt(10))
+	union.Set(&v, benchString("10"))
+
+	// Alternating union types from many goroutines must not contend on the layout cache.
+	b.RunParallel(func(pb *testing.PB) {
+		var sink bool
+		for pb.Next() {
+			sink = union.Is[benchInt](u) != union.Is[benchString](v)
+		}
+
+		benchSharedSink.Store(sink)
+	})
+}
+
+func BenchmarkUnionGetSafe(b *testing.B) {
+	var u benchUnion
+	union.Set(&u, benchInt(10))
+	for range b.N {
+		v, _ := union.GetSafe[benchInt](u)
+		benchSink += int64(v)
+	}
+}
+
+func BenchmarkUnionSwitch(b *testing.B) {
+	s := union.MustSwitch[benchUnion](
+		union.On(func(v benchInt) int64 { return int64(v) }),
+		union.On(func(v benchFloat) int64 { return int64(v) }),
+		union.On(func(v benchString) int64 { return int64(len(v)) }),
+	)
+
+	var u benchUnion
+	for i := range b.N {
+		union.Set(&u, benchInt(i))
+		benchSink += s.Match(u)
+	}
+}
+
+func BenchmarkInterfaceSetGet(b *testing.B) {
+	for i := range b.N {
+		benchSumSink = benchInt(i)
+		benchSink += int64(benchSumSink.(benchInt))
+	}
+}
+
+func BenchmarkInterfaceTypeSwitch(b *testing.B) {
+	for i := range b.N {
+		benchSumSink = benchInt(i)
+		switch v := benchSumSink.(type) {
+		case benchInt:
+			benchSink += int64(v)
+		case benchFloat:
+			benchSink += int64(v)
+		case benchString:
+			benchSink += int64(len(v))
+		}
+	}
+}
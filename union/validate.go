@@ -0,0 +1,85 @@
+package union
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+var ErrInvalidDefinition = errors.New("invalid union definition")
+
+// Validate checks that the internal type of a union is a valid definition,
+// returning an error describing every problem found.
+//
+// A valid union is a struct of at most 255 embedded members with distinct types and distinct names,
+// where a member's name can be overridden with a 'union' struct tag:
+//
+//	type Value = union.Of[struct {
+//		int32
+//		float32
+//	}]
+//
+//	if err := union.Validate[Value](); err != nil {
+//		...
+//	}
+func Validate[U Union[T], T anystruct]() error {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("%s is not a struct - %w", t, ErrInvalidDefinition)
+	}
+
+	if t.NumField() == 0 {
+		return fmt.Errorf("%s has no members - %w", t, ErrInvalidDefinition)
+	}
+
+	var errs []error
+	if t.NumField() > math.MaxUint8 {
+		errs = append(errs, fmt.Errorf("%d members exceeds the limit of %d - %w", t.NumField(), math.MaxUint8, ErrInvalidDefinition))
+	}
+
+	seen := make(map[reflect.Type]int)
+	names := make(map[string]int)
+	for i := range t.NumField() {
+		field := t.Field(i)
+		switch {
+		case field.Name == "_":
+			errs = append(errs, fmt.Errorf("member %d (%s) is a blank field - %w", i, field.Type, ErrInvalidDefinition))
+		case !field.Anonymous:
+			errs = append(errs, fmt.Errorf("member %d (%s %s) is not embedded - %w", i, field.Name, field.Type, ErrInvalidDefinition))
+		}
+
+		if first, ok := seen[field.Type]; ok {
+			errs = append(errs, fmt.Errorf("member %d (%s) has the same type as member %d - %w", i, field.Type, first, ErrInvalidDefinition))
+		} else {
+			seen[field.Type] = i
+		}
+
+		if field.Name == "_" {
+			continue
+		}
+
+		// Encodings identify members by name, so a repeated name would decode into the wrong member.
+		if first, ok := names[memberName(field)]; ok {
+			errs = append(errs, fmt.Errorf("member %d (%s) has the same name %q as member %d - %w", i, field.Type, memberName(field), first, ErrInvalidDefinition))
+		} else {
+			names[memberName(field)] = i
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// MustDefine returns an uninitialized union, panicking if its definition is invalid.
+//
+// It's intended to be used at the package level so invalid unions are reported at init time:
+//
+//	var _ = union.MustDefine[Value]()
+func MustDefine[U Union[T], T anystruct]() U {
+	if err := Validate[U](); err != nil {
+		panic(err)
+	}
+
+	var zero U
+	return zero
+}
@@ -0,0 +1,76 @@
+package union_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/judah-caruso/unsafex/union"
+)
+
+func TestValidate(t *testing.T) {
+	if err := union.Validate[expr](); err != nil {
+		t.Errorf("expected valid union to pass validation: %s", err)
+	}
+
+	type Invalid = union.Of[struct {
+		int32
+		A int32
+		_ float32
+	}]
+
+	err := union.Validate[Invalid]()
+	if !errors.Is(err, union.ErrInvalidDefinition) {
+		t.Fatalf("expected invalid union to fail validation: %v", err)
+	}
+
+	for _, problem := range []string{
+		"member 1 (A int32) is not embedded",
+		"member 1 (int32) has the same type as member 0",
+		"member 2 (float32) is a blank field",
+	} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("expected validation error to contain %q:\n%s", problem, err)
+		}
+	}
+
+	type Renamed = union.Of[struct {
+		int32 `union:"n"`
+		int64 `union:"n"`
+		float32
+		float64 `union:"float32"`
+	}]
+
+	err = union.Validate[Renamed]()
+	for _, problem := range []string{
+		`member 1 (int64) has the same name "n" as member 0`,
+		`member 3 (float64) has the same name "float32" as member 2`,
+	} {
+		if !errors.Is(err, union.ErrInvalidDefinition) || !strings.Contains(err.Error(), problem) {
+			t.Errorf("expected validation error to contain %q:\n%v", problem, err)
+		}
+	}
+
+	if err := union.Validate[union.Of[int]](); !errors.Is(err, union.ErrInvalidDefinition) {
+		t.Errorf("expected non-struct union to fail validation: %v", err)
+	}
+
+	if err := union.Validate[union.Of[struct{}]](); !errors.Is(err, union.ErrInvalidDefinition) {
+		t.Errorf("expected empty union to fail validation: %v", err)
+	}
+}
+
+func TestMustDefine(t *testing.T) {
+	e := union.MustDefine[expr]()
+	if union.Is[intExpr](e) {
+		t.Error("expected MustDefine to return an uninitialized union")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustDefine to panic for an invalid union")
+		}
+	}()
+
+	union.MustDefine[union.Of[struct{ A, B int }]]()
+}
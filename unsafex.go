@@ -55,3 +55,30 @@ func AsInt(b bool) int {
 	// @note(judah): we cast to int8 instead of uint8 to ensure the sign persists.
 	return int(*(*int8)(unsafe.Pointer(&b)))
 }
+
+// IsPowerOfTwo returns if n is a power of two, as required for alignments.
+func IsPowerOfTwo(n uintptr) bool {
+	return n != 0 && n&(n-1) == 0
+}
+
+// AlignForward rounds x up to the next multiple of align, which must be a power of two.
+//
+// Note: AlignForward returns x if it's already aligned.
+func AlignForward(x, align uintptr) uintptr {
+	Assert(IsPowerOfTwo(align), "alignment %d is not a power of two", align)
+	return (x + align - 1) & ^(align - 1)
+}
+
+// AlignBackward rounds x down to the previous multiple of align, which must be a power of two.
+//
+// Note: AlignBackward returns x if it's already aligned.
+func AlignBackward(x, align uintptr) uintptr {
+	Assert(IsPowerOfTwo(align), "alignment %d is not a power of two", align)
+	return x & ^(align - 1)
+}
+
+// IsAligned returns if x is a multiple of align, which must be a power of two.
+func IsAligned(x, align uintptr) bool {
+	Assert(IsPowerOfTwo(align), "alignment %d is not a power of two", align)
+	return x&(align-1) == 0
+}
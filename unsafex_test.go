@@ -210,3 +210,39 @@ func TestAsBool(t *testing.T) {
 		t.Errorf("conversion from int to bool and back was incorrect %d vs %d", a, b)
 	}
 }
+
+func TestAlign(t *testing.T) {
+	cases := []struct {
+		x, align          uintptr
+		forward, backward uintptr
+	}{
+		{0, 8, 0, 0},
+		{1, 1, 1, 1},
+		{1, 8, 8, 0},
+		{8, 8, 8, 8},
+		{9, 16, 16, 0},
+		{100, 64, 128, 64},
+		{4097, 4096, 8192, 4096},
+		{33, 32, 64, 32},
+	}
+
+	for _, c := range cases {
+		if v := unsafex.AlignForward(c.x, c.align); v != c.forward {
+			t.Errorf("expected %d aligned forward to %d to be %d, was %d", c.x, c.align, c.forward, v)
+		}
+
+		if v := unsafex.AlignBackward(c.x, c.align); v != c.backward {
+			t.Errorf("expected %d aligned backward to %d to be %d, was %d", c.x, c.align, c.backward, v)
+		}
+
+		if aligned := unsafex.IsAligned(c.x, c.align); aligned != (c.x == c.forward) {
+			t.Errorf("expected IsAligned(%d, %d) to be %v", c.x, c.align, !aligned)
+		}
+	}
+
+	for _, n := range []uintptr{0, 3, 6, 100} {
+		if unsafex.IsPowerOfTwo(n) {
+			t.Errorf("expected %d to not be a power of two", n)
+		}
+	}
+}